@@ -0,0 +1,33 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"No_ai/internal/menageriedoc"
+)
+
+func main() {
+	dir := flag.String("dir", "internal/menagerie", "directory of the package to document")
+	out := flag.String("o", "", "output file; empty or '-' means stdout")
+	flag.Parse()
+
+	animals, err := menageriedoc.Extract(*dir)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	markdown := menageriedoc.Render(animals)
+
+	if *out == "" || *out == "-" {
+		fmt.Print(markdown)
+		return
+	}
+
+	if err := os.WriteFile(*out, []byte(markdown), 0o644); err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+}
@@ -1,29 +1,54 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"No_ai/internal/api"
+	"No_ai/internal/config"
 )
 
-type cat struct {
-	name           string
-	colorPrimary   string
-	colorSecondary string
-	numberLegs     int
-	numberEyes     int
-}
+func main() {
+	fmt.Println("Hello World")
 
-func newCat(name, colorPrimary, colorSecondary string) *cat {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
 
-	c := cat{name: name, colorPrimary: colorPrimary, colorSecondary: colorSecondary}
-	c.numberLegs = 4
-	c.numberEyes = 2
-	return &c
-}
+	animals, err := cfg.BuildAnimals()
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
 
-func main() {
-	fmt.Println("Hello World")
-	kitty := newCat("mittens", "black", "yellow")
+	srv, err := api.Start(":8080")
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	if err := srv.Seed(animals); err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Listening on :8080")
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	<-stop
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	kittyInfo := fmt.Sprintf("Name: %s, Color Primary: %s, Color Secondary: %s", kitty.name, kitty.colorPrimary, kitty.colorSecondary)
-	fmt.Println(kittyInfo)
+	if err := srv.Stop(ctx); err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
 }
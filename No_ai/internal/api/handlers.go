@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"No_ai/internal/menagerie"
+)
+
+// animalJSON is the wire representation of a menagerie.Animal. It mirrors
+// the fields of cat (and its siblings) with explicit JSON tags, since the
+// unexported struct fields behind the Animal interface can't be marshaled
+// directly.
+type animalJSON struct {
+	Kind           string `json:"kind"`
+	Name           string `json:"name"`
+	ColorPrimary   string `json:"color_primary"`
+	ColorSecondary string `json:"color_secondary"`
+	Legs           int    `json:"legs"`
+	Eyes           int    `json:"eyes"`
+}
+
+// toAnimalJSON converts a menagerie.Animal to its wire representation.
+func toAnimalJSON(a menagerie.Animal) animalJSON {
+	return animalJSON{
+		Kind:           a.Kind(),
+		Name:           a.Name(),
+		ColorPrimary:   a.ColorPrimary(),
+		ColorSecondary: a.ColorSecondary(),
+		Legs:           a.Legs(),
+		Eyes:           a.Eyes(),
+	}
+}
+
+// writeJSON marshals v as the HTTP response body with the given status code.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error body of the form {"error": "..."}.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// handleAnimals routes GET /animals and POST /animals.
+func (s *Server) handleAnimals(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.handleListAnimals(w, r)
+	case http.MethodPost:
+		s.handleCreateAnimal(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleAnimal routes GET /animals/{name} and DELETE /animals/{name}.
+func (s *Server) handleAnimal(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/animals/")
+	if name == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		s.handleGetAnimal(w, r, name)
+	case http.MethodDelete:
+		s.handleDeleteAnimal(w, r, name)
+	default:
+		w.Header().Set("Allow", "GET, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleListAnimals(w http.ResponseWriter, r *http.Request) {
+	animals := s.store.List()
+
+	out := make([]animalJSON, 0, len(animals))
+	for _, a := range animals {
+		out = append(out, toAnimalJSON(a))
+	}
+
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (s *Server) handleGetAnimal(w http.ResponseWriter, r *http.Request, name string) {
+	a, ok := s.store.Get(name)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, toAnimalJSON(a))
+}
+
+func (s *Server) handleCreateAnimal(w http.ResponseWriter, r *http.Request) {
+	var body animalJSON
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	a, err := menagerie.New(body.Kind, body.Name, body.ColorPrimary, body.ColorSecondary)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := s.store.Add(a); err != nil {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, toAnimalJSON(a))
+}
+
+func (s *Server) handleDeleteAnimal(w http.ResponseWriter, r *http.Request, name string) {
+	if !s.store.Delete(name) {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,64 @@
+// Package api exposes the menagerie over an HTTP REST API backed by a
+// thread-safe in-memory store.
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"No_ai/internal/menagerie"
+)
+
+// Server wraps an *http.Server and the in-memory animal store it serves.
+type Server struct {
+	store *store
+	http  *http.Server
+}
+
+// NewServer is an initializer function for Server.
+// It wires up the routes but does not start listening; Start builds its own
+// Server this way and then serves it.
+func NewServer() *Server {
+	s := &Server{store: newStore()}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/animals", s.handleAnimals)
+	mux.HandleFunc("/animals/", s.handleAnimal)
+
+	s.http = &http.Server{Handler: mux}
+
+	return s
+}
+
+// Seed adds each of the given animals to the server's store, for populating
+// it at startup from config.Load.
+func (s *Server) Seed(animals []menagerie.Animal) error {
+	for _, a := range animals {
+		if err := s.store.Add(a); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start binds addr and begins serving in a background goroutine, returning
+// once the listener is ready so callers know the server is reachable.
+func Start(addr string) (*Server, error) {
+	s := NewServer()
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	go s.http.Serve(ln)
+
+	return s, nil
+}
+
+// Stop gracefully shuts the server down, waiting for in-flight requests to
+// finish or ctx to be done, whichever comes first.
+func (s *Server) Stop(ctx context.Context) error {
+	return s.http.Shutdown(ctx)
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestServer() *Server {
+	return NewServer()
+}
+
+func TestListAnimalsEmpty(t *testing.T) {
+	s := newTestServer()
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/animals", nil)
+
+	s.handleAnimals(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var got []animalJSON
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("expected no animals, got %v", got)
+	}
+}
+
+func TestCreateAndGetAnimal(t *testing.T) {
+	s := newTestServer()
+
+	body, _ := json.Marshal(animalJSON{Kind: "cat", Name: "mittens", ColorPrimary: "black", ColorSecondary: "yellow"})
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/animals", bytes.NewReader(body))
+
+	s.handleAnimals(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/animals/mittens", nil)
+	s.handleAnimal(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var got animalJSON
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.Name != "mittens" || got.Legs != 4 || got.Eyes != 2 {
+		t.Fatalf("unexpected animal: %+v", got)
+	}
+}
+
+func TestCreateAnimalRejectsDuplicateName(t *testing.T) {
+	s := newTestServer()
+
+	body, _ := json.Marshal(animalJSON{Kind: "cat", Name: "mittens", ColorPrimary: "black", ColorSecondary: "yellow"})
+
+	rec := httptest.NewRecorder()
+	s.handleAnimals(rec, httptest.NewRequest(http.MethodPost, "/animals", bytes.NewReader(body)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected first create to succeed, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	s.handleAnimals(rec, httptest.NewRequest(http.MethodPost, "/animals", bytes.NewReader(body)))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected 409 on duplicate name, got %d", rec.Code)
+	}
+}
+
+func TestCreateAnimalRejectsUnknownKind(t *testing.T) {
+	s := newTestServer()
+
+	body, _ := json.Marshal(animalJSON{Kind: "dragon", Name: "smaug"})
+	rec := httptest.NewRecorder()
+	s.handleAnimals(rec, httptest.NewRequest(http.MethodPost, "/animals", bytes.NewReader(body)))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown kind, got %d", rec.Code)
+	}
+}
+
+func TestGetAnimalNotFound(t *testing.T) {
+	s := newTestServer()
+	rec := httptest.NewRecorder()
+	s.handleAnimal(rec, httptest.NewRequest(http.MethodGet, "/animals/nobody", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
+
+func TestDeleteAnimal(t *testing.T) {
+	s := newTestServer()
+
+	body, _ := json.Marshal(animalJSON{Kind: "dog", Name: "rex", ColorPrimary: "brown", ColorSecondary: "white"})
+	rec := httptest.NewRecorder()
+	s.handleAnimals(rec, httptest.NewRequest(http.MethodPost, "/animals", bytes.NewReader(body)))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected create to succeed, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	s.handleAnimal(rec, httptest.NewRequest(http.MethodDelete, "/animals/rex", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	s.handleAnimal(rec, httptest.NewRequest(http.MethodGet, "/animals/rex", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 after delete, got %d", rec.Code)
+	}
+}
+
+func TestDeleteAnimalNotFound(t *testing.T) {
+	s := newTestServer()
+	rec := httptest.NewRecorder()
+	s.handleAnimal(rec, httptest.NewRequest(http.MethodDelete, "/animals/nobody", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
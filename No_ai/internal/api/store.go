@@ -0,0 +1,74 @@
+package api
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"No_ai/internal/menagerie"
+)
+
+// store is a mutex-guarded, in-memory collection of menagerie.Animal values
+// keyed by name.
+type store struct {
+	mu      sync.Mutex
+	animals map[string]menagerie.Animal
+}
+
+// newStore is an initializer function for store.
+// It returns an empty store ready to hold animals.
+func newStore() *store {
+	return &store{animals: make(map[string]menagerie.Animal)}
+}
+
+// List returns every animal in the store, ordered by name so responses are
+// stable across calls.
+func (s *store) List() []menagerie.Animal {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	animals := make([]menagerie.Animal, 0, len(s.animals))
+	for _, a := range s.animals {
+		animals = append(animals, a)
+	}
+	sort.Slice(animals, func(i, j int) bool { return animals[i].Name() < animals[j].Name() })
+
+	return animals
+}
+
+// Get returns the animal with the given name, or false if none exists.
+func (s *store) Get(name string) (menagerie.Animal, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	a, ok := s.animals[name]
+	return a, ok
+}
+
+// Add inserts animal into the store, returning an error if its name is
+// already taken.
+func (s *store) Add(a menagerie.Animal) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.animals[a.Name()]; exists {
+		return fmt.Errorf("an animal named %q already exists", a.Name())
+	}
+	s.animals[a.Name()] = a
+
+	return nil
+}
+
+// Delete removes the animal with the given name, returning false if it was
+// not present.
+func (s *store) Delete(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.animals[name]; !exists {
+		return false
+	}
+	delete(s.animals, name)
+
+	return true
+}
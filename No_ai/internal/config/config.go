@@ -0,0 +1,134 @@
+// Package config loads the menagerie's startup population from a
+// menagerie.yaml file in the user's XDG config directory, creating a
+// default one on first run.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"No_ai/internal/menagerie"
+)
+
+// defaultConfigYAML is written to disk the first time Load runs and no
+// config file exists yet.
+const defaultConfigYAML = `animals:
+  - kind: cat
+    name: mittens
+    color_primary: black
+    color_secondary: yellow
+`
+
+// AnimalConfig describes one entry in menagerie.yaml. Legs and Eyes are
+// pointers so an absent field can be told apart from an explicit zero,
+// meaning "use the constructor's default".
+type AnimalConfig struct {
+	Kind           string `yaml:"kind"`
+	Name           string `yaml:"name"`
+	ColorPrimary   string `yaml:"color_primary"`
+	ColorSecondary string `yaml:"color_secondary"`
+	Legs           *int   `yaml:"legs,omitempty"`
+	Eyes           *int   `yaml:"eyes,omitempty"`
+}
+
+// Config is the parsed contents of menagerie.yaml.
+type Config struct {
+	Animals []AnimalConfig `yaml:"animals"`
+}
+
+// NewConfig is an initializer function for Config.
+// It returns an empty config with no animals.
+func NewConfig() *Config {
+	return &Config{}
+}
+
+// legEyeSetter is satisfied by every menagerie.Animal implementation; it is
+// used to apply an AnimalConfig's optional Legs/Eyes overrides after
+// construction.
+type legEyeSetter interface {
+	SetLegs(int)
+	SetEyes(int)
+}
+
+// configDir returns the directory menagerie.yaml lives in, honoring
+// XDG_CONFIG_HOME (os.UserConfigDir reads it on Linux).
+func configDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to determine config directory: %w", err)
+	}
+	return filepath.Join(dir, "menagerie"), nil
+}
+
+// configPath returns the full path to menagerie.yaml.
+func configPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "menagerie.yaml"), nil
+}
+
+// Load reads menagerie.yaml from the XDG config directory, writing and then
+// re-reading the embedded default config if no file exists yet.
+func Load() (*Config, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create config directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(defaultConfigYAML), 0o644); err != nil {
+			return nil, fmt.Errorf("failed to write default config %q: %w", path, err)
+		}
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config %q: %w", path, err)
+	}
+
+	cfg := NewConfig()
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config %q: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// BuildAnimals builds a menagerie.Animal for every entry in cfg.Animals,
+// dispatching through menagerie.New by Kind and applying any Legs/Eyes
+// overrides.
+func (cfg *Config) BuildAnimals() ([]menagerie.Animal, error) {
+	animals := make([]menagerie.Animal, 0, len(cfg.Animals))
+
+	for _, a := range cfg.Animals {
+		animal, err := menagerie.New(a.Kind, a.Name, a.ColorPrimary, a.ColorSecondary)
+		if err != nil {
+			return nil, err
+		}
+
+		if a.Legs != nil || a.Eyes != nil {
+			setter, ok := animal.(legEyeSetter)
+			if !ok {
+				return nil, fmt.Errorf("animal kind %q does not support leg/eye overrides", a.Kind)
+			}
+			if a.Legs != nil {
+				setter.SetLegs(*a.Legs)
+			}
+			if a.Eyes != nil {
+				setter.SetEyes(*a.Eyes)
+			}
+		}
+
+		animals = append(animals, animal)
+	}
+
+	return animals, nil
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withTempConfigHome points XDG_CONFIG_HOME at a fresh temp directory for
+// the duration of the test, restoring the previous value afterward.
+func withTempConfigHome(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	old, had := os.LookupEnv("XDG_CONFIG_HOME")
+	os.Setenv("XDG_CONFIG_HOME", dir)
+	t.Cleanup(func() {
+		if had {
+			os.Setenv("XDG_CONFIG_HOME", old)
+		} else {
+			os.Unsetenv("XDG_CONFIG_HOME")
+		}
+	})
+
+	return dir
+}
+
+func TestLoadCreatesDefaultConfig(t *testing.T) {
+	home := withTempConfigHome(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if len(cfg.Animals) != 1 || cfg.Animals[0].Kind != "cat" {
+		t.Fatalf("expected the default config to describe one cat, got %+v", cfg.Animals)
+	}
+
+	path := filepath.Join(home, "menagerie", "menagerie.yaml")
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected default config to be written to %q: %v", path, err)
+	}
+}
+
+func TestLoadReadsExistingConfig(t *testing.T) {
+	home := withTempConfigHome(t)
+
+	dir := filepath.Join(home, "menagerie")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+	yamlContent := "animals:\n  - kind: dog\n    name: rex\n    color_primary: brown\n    color_secondary: white\n"
+	if err := os.WriteFile(filepath.Join(dir, "menagerie.yaml"), []byte(yamlContent), 0o644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(cfg.Animals) != 1 || cfg.Animals[0].Kind != "dog" || cfg.Animals[0].Name != "rex" {
+		t.Fatalf("expected one dog named rex, got %+v", cfg.Animals)
+	}
+}
+
+func TestBuildAnimalsDispatchesAndAppliesOverrides(t *testing.T) {
+	legs := 3
+	cfg := &Config{Animals: []AnimalConfig{
+		{Kind: "spider", Name: "charlotte", ColorPrimary: "gray", ColorSecondary: "black", Legs: &legs},
+	}}
+
+	animals, err := cfg.BuildAnimals()
+	if err != nil {
+		t.Fatalf("BuildAnimals failed: %v", err)
+	}
+	if len(animals) != 1 {
+		t.Fatalf("expected 1 animal, got %d", len(animals))
+	}
+	if animals[0].Legs() != legs {
+		t.Fatalf("expected leg override of %d, got %d", legs, animals[0].Legs())
+	}
+}
+
+func TestBuildAnimalsRejectsUnknownKind(t *testing.T) {
+	cfg := &Config{Animals: []AnimalConfig{{Kind: "dragon", Name: "smaug"}}}
+
+	if _, err := cfg.BuildAnimals(); err == nil {
+		t.Fatal("expected an error for an unknown animal kind")
+	}
+}
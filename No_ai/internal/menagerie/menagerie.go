@@ -0,0 +1,173 @@
+// Package menagerie defines the Animal interface and the concrete animal
+// types that implement it.
+package menagerie
+
+import "fmt"
+
+// Animal is implemented by every creature in the menagerie. Satisfying
+// fmt.Stringer lets callers print an Animal directly instead of building
+// their own description string.
+type Animal interface {
+	fmt.Stringer
+	Kind() string
+	Name() string
+	ColorPrimary() string
+	ColorSecondary() string
+	Legs() int
+	Eyes() int
+}
+
+// constructors maps an animal kind to the function that builds it. New
+// dispatches through this table; callers that need to build an Animal from
+// a string (a config file, an HTTP request body) should go through New
+// rather than keeping their own copy of this mapping.
+var constructors = map[string]func(name, colorPrimary, colorSecondary string) Animal{
+	"cat":    func(name, p, s string) Animal { return NewCat(name, p, s) },
+	"dog":    func(name, p, s string) Animal { return NewDog(name, p, s) },
+	"spider": func(name, p, s string) Animal { return NewSpider(name, p, s) },
+	"bird":   func(name, p, s string) Animal { return NewBird(name, p, s) },
+}
+
+// New builds the Animal for the given kind ("cat", "dog", "spider", "bird"),
+// returning an error if kind is not recognized.
+func New(kind, name, colorPrimary, colorSecondary string) (Animal, error) {
+	ctor, ok := constructors[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown animal kind %q", kind)
+	}
+	return ctor(name, colorPrimary, colorSecondary), nil
+}
+
+// cat is a four-legged, two-eyed Animal.
+type cat struct {
+	name           string
+	colorPrimary   string
+	colorSecondary string
+	numberLegs     int
+	numberEyes     int
+}
+
+// NewCat is an initializer function for cat.
+// It sets the defaults for a typical house cat: four legs, two eyes.
+func NewCat(name, colorPrimary, colorSecondary string) *cat {
+	c := cat{name: name, colorPrimary: colorPrimary, colorSecondary: colorSecondary}
+	c.numberLegs = 4
+	c.numberEyes = 2
+	return &c
+}
+
+func (c *cat) Name() string           { return c.name }
+func (c *cat) Legs() int              { return c.numberLegs }
+func (c *cat) Eyes() int              { return c.numberEyes }
+func (c *cat) Kind() string           { return "cat" }
+func (c *cat) ColorPrimary() string   { return c.colorPrimary }
+func (c *cat) ColorSecondary() string { return c.colorSecondary }
+
+// SetLegs and SetEyes let a caller override the defaults NewCat sets, for
+// example when a config file specifies a non-standard leg or eye count.
+func (c *cat) SetLegs(n int) { c.numberLegs = n }
+func (c *cat) SetEyes(n int) { c.numberEyes = n }
+
+// String renders a cat the same way the hand-built kittyInfo string used to.
+func (c *cat) String() string {
+	return fmt.Sprintf("Name: %s, Color Primary: %s, Color Secondary: %s", c.name, c.colorPrimary, c.colorSecondary)
+}
+
+// dog is a four-legged, two-eyed Animal.
+type dog struct {
+	name           string
+	colorPrimary   string
+	colorSecondary string
+	numberLegs     int
+	numberEyes     int
+}
+
+// NewDog is an initializer function for dog.
+// It sets the defaults for a typical dog: four legs, two eyes.
+func NewDog(name, colorPrimary, colorSecondary string) *dog {
+	d := dog{name: name, colorPrimary: colorPrimary, colorSecondary: colorSecondary}
+	d.numberLegs = 4
+	d.numberEyes = 2
+	return &d
+}
+
+func (d *dog) Name() string           { return d.name }
+func (d *dog) Legs() int              { return d.numberLegs }
+func (d *dog) Eyes() int              { return d.numberEyes }
+func (d *dog) Kind() string           { return "dog" }
+func (d *dog) ColorPrimary() string   { return d.colorPrimary }
+func (d *dog) ColorSecondary() string { return d.colorSecondary }
+
+// SetLegs and SetEyes let a caller override the defaults NewDog sets.
+func (d *dog) SetLegs(n int) { d.numberLegs = n }
+func (d *dog) SetEyes(n int) { d.numberEyes = n }
+
+func (d *dog) String() string {
+	return fmt.Sprintf("Name: %s, Color Primary: %s, Color Secondary: %s", d.name, d.colorPrimary, d.colorSecondary)
+}
+
+// spider is an eight-legged, eight-eyed Animal.
+type spider struct {
+	name           string
+	colorPrimary   string
+	colorSecondary string
+	numberLegs     int
+	numberEyes     int
+}
+
+// NewSpider is an initializer function for spider.
+// It sets the defaults for a typical spider: eight legs, eight eyes.
+func NewSpider(name, colorPrimary, colorSecondary string) *spider {
+	s := spider{name: name, colorPrimary: colorPrimary, colorSecondary: colorSecondary}
+	s.numberLegs = 8
+	s.numberEyes = 8
+	return &s
+}
+
+func (s *spider) Name() string           { return s.name }
+func (s *spider) Legs() int              { return s.numberLegs }
+func (s *spider) Eyes() int              { return s.numberEyes }
+func (s *spider) Kind() string           { return "spider" }
+func (s *spider) ColorPrimary() string   { return s.colorPrimary }
+func (s *spider) ColorSecondary() string { return s.colorSecondary }
+
+// SetLegs and SetEyes let a caller override the defaults NewSpider sets.
+func (s *spider) SetLegs(n int) { s.numberLegs = n }
+func (s *spider) SetEyes(n int) { s.numberEyes = n }
+
+func (s *spider) String() string {
+	return fmt.Sprintf("Name: %s, Color Primary: %s, Color Secondary: %s", s.name, s.colorPrimary, s.colorSecondary)
+}
+
+// bird is a two-legged, two-eyed Animal.
+type bird struct {
+	name           string
+	colorPrimary   string
+	colorSecondary string
+	numberLegs     int
+	numberEyes     int
+}
+
+// NewBird is an initializer function for bird.
+// It sets the defaults for a typical bird: two legs, two eyes.
+func NewBird(name, colorPrimary, colorSecondary string) *bird {
+	b := bird{name: name, colorPrimary: colorPrimary, colorSecondary: colorSecondary}
+	b.numberLegs = 2
+	b.numberEyes = 2
+	return &b
+}
+
+func (b *bird) Name() string           { return b.name }
+func (b *bird) Legs() int              { return b.numberLegs }
+func (b *bird) Eyes() int              { return b.numberEyes }
+func (b *bird) Kind() string           { return "bird" }
+func (b *bird) ColorPrimary() string   { return b.colorPrimary }
+func (b *bird) ColorSecondary() string { return b.colorSecondary }
+
+// SetLegs and SetEyes let a caller override the defaults NewBird sets.
+func (b *bird) SetLegs(n int) { b.numberLegs = n }
+func (b *bird) SetEyes(n int) { b.numberEyes = n }
+
+func (b *bird) String() string {
+	return fmt.Sprintf("Name: %s, Color Primary: %s, Color Secondary: %s", b.name, b.colorPrimary, b.colorSecondary)
+}
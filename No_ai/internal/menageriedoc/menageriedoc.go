@@ -0,0 +1,172 @@
+// Package menageriedoc extracts a Markdown reference for the menagerie
+// package's Animal implementations: their doc comment, constructor
+// signature, and the leg/eye defaults set in that constructor.
+package menageriedoc
+
+import (
+	"fmt"
+	"go/ast"
+	"go/doc"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// animalMethods are the Animal interface methods used to recognize which
+// doc.Type entries are Animal implementations. This is a heuristic (the
+// package has no real type-checking pass), but it is exact for any type
+// that actually implements menagerie.Animal.
+var animalMethods = []string{"Name", "Legs", "Eyes"}
+
+// AnimalDoc describes one Animal implementation: its name, doc comment,
+// constructor signature, and the leg/eye defaults that constructor sets.
+type AnimalDoc struct {
+	TypeName    string
+	Doc         string
+	Constructor string
+	NumberLegs  int
+	NumberEyes  int
+	HasLegs     bool
+	HasEyes     bool
+}
+
+// NewAnimalDoc is an initializer function for AnimalDoc.
+// It returns an AnimalDoc with just the type name set.
+func NewAnimalDoc(typeName string) *AnimalDoc {
+	return &AnimalDoc{TypeName: typeName}
+}
+
+// Extract parses the Go package rooted at dir and returns an AnimalDoc for
+// every type that implements the Animal interface, sorted by type name so
+// output is reproducible.
+func Extract(dir string) ([]AnimalDoc, error) {
+	fset := token.NewFileSet()
+
+	pkgs, err := parser.ParseDir(fset, dir, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", dir, err)
+	}
+
+	// go/doc.New takes ownership of the *ast.Package it is given and strips
+	// function bodies from it (and files a factory function like NewCat
+	// under its return type's Funcs rather than the package-level Funcs
+	// list). So constructors are looked up in a second, independent parse
+	// that still has bodies intact, rather than through docPkg.
+	declFset := token.NewFileSet()
+	declPkgs, err := parser.ParseDir(declFset, dir, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q: %w", dir, err)
+	}
+	declsByName := make(map[string]*ast.FuncDecl)
+	for _, pkg := range declPkgs {
+		for _, f := range pkg.Files {
+			for _, d := range f.Decls {
+				if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv == nil {
+					declsByName[fn.Name.Name] = fn
+				}
+			}
+		}
+	}
+
+	var results []AnimalDoc
+	for _, pkg := range pkgs {
+		docPkg := doc.New(pkg, dir, doc.AllDecls)
+
+		for _, t := range docPkg.Types {
+			if !implementsAnimal(t) {
+				continue
+			}
+
+			ad := NewAnimalDoc(t.Name)
+			ad.Doc = strings.TrimSpace(t.Doc)
+
+			if decl := declsByName["New"+strings.Title(t.Name)]; decl != nil {
+				ad.Constructor = renderFuncSignature(declFset, decl)
+				legs, eyes, hasLegs, hasEyes := constructorDefaults(decl)
+				ad.NumberLegs, ad.NumberEyes = legs, eyes
+				ad.HasLegs, ad.HasEyes = hasLegs, hasEyes
+			}
+
+			results = append(results, *ad)
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool { return results[i].TypeName < results[j].TypeName })
+
+	return results, nil
+}
+
+// implementsAnimal reports whether t declares every method in
+// animalMethods, directly or via a pointer receiver function of the same
+// name associated with t.
+func implementsAnimal(t *doc.Type) bool {
+	have := make(map[string]bool, len(t.Methods))
+	for _, m := range t.Methods {
+		have[m.Name] = true
+	}
+
+	for _, name := range animalMethods {
+		if !have[name] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// renderFuncSignature renders just the "func Name(params) results" line of
+// a function declaration, dropping its body.
+func renderFuncSignature(fset *token.FileSet, decl *ast.FuncDecl) string {
+	sig := &ast.FuncDecl{
+		Name: decl.Name,
+		Type: decl.Type,
+	}
+
+	var buf strings.Builder
+	printer.Fprint(&buf, fset, sig)
+
+	return buf.String()
+}
+
+// constructorDefaults walks decl's body for assignments of the form
+// `x.numberLegs = <int literal>` / `x.numberEyes = <int literal>`, the
+// pattern every New* constructor in the menagerie package uses to set its
+// defaults.
+func constructorDefaults(decl *ast.FuncDecl) (legs, eyes int, hasLegs, hasEyes bool) {
+	if decl.Body == nil {
+		return 0, 0, false, false
+	}
+
+	for _, stmt := range decl.Body.List {
+		assign, ok := stmt.(*ast.AssignStmt)
+		if !ok || len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+			continue
+		}
+
+		sel, ok := assign.Lhs[0].(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+
+		lit, ok := assign.Rhs[0].(*ast.BasicLit)
+		if !ok || lit.Kind != token.INT {
+			continue
+		}
+		n, err := strconv.Atoi(lit.Value)
+		if err != nil {
+			continue
+		}
+
+		switch sel.Sel.Name {
+		case "numberLegs":
+			legs, hasLegs = n, true
+		case "numberEyes":
+			eyes, hasEyes = n, true
+		}
+	}
+
+	return legs, eyes, hasLegs, hasEyes
+}
@@ -0,0 +1,46 @@
+package menageriedoc
+
+import (
+	"os"
+	"testing"
+)
+
+// TestExtractAndRenderMatchesGolden regenerates the Markdown reference for
+// the menagerie package and compares it against the checked-in golden file,
+// so changes to animal doc comments, constructors, or defaults are caught
+// in review.
+func TestExtractAndRenderMatchesGolden(t *testing.T) {
+	animals, err := Extract("../menagerie")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	got := Render(animals)
+
+	want, err := os.ReadFile("testdata/golden.md")
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+
+	if got != string(want) {
+		t.Fatalf("rendered markdown does not match golden file\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestExtractIsReproducible guards the stable-ordering guarantee Extract
+// promises: running it twice over the same package must produce identical
+// output.
+func TestExtractIsReproducible(t *testing.T) {
+	first, err := Extract("../menagerie")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+	second, err := Extract("../menagerie")
+	if err != nil {
+		t.Fatalf("Extract failed: %v", err)
+	}
+
+	if Render(first) != Render(second) {
+		t.Fatal("Extract produced different output across repeated runs")
+	}
+}
@@ -0,0 +1,39 @@
+package menageriedoc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Render renders animals as a Markdown reference, one section per animal in
+// the order given (callers should pass the already-sorted slice Extract
+// returns so output stays reproducible).
+func Render(animals []AnimalDoc) string {
+	var b strings.Builder
+
+	b.WriteString("# Menagerie Animal Reference\n")
+
+	for _, a := range animals {
+		fmt.Fprintf(&b, "\n## %s\n\n", a.TypeName)
+
+		if a.Doc != "" {
+			fmt.Fprintf(&b, "%s\n\n", a.Doc)
+		}
+
+		if a.Constructor != "" {
+			fmt.Fprintf(&b, "**Constructor:** `%s`\n\n", a.Constructor)
+		}
+
+		if a.HasLegs || a.HasEyes {
+			b.WriteString("**Defaults:**\n\n")
+			if a.HasLegs {
+				fmt.Fprintf(&b, "- Legs: %d\n", a.NumberLegs)
+			}
+			if a.HasEyes {
+				fmt.Fprintf(&b, "- Eyes: %d\n", a.NumberEyes)
+			}
+		}
+	}
+
+	return b.String()
+}
@@ -7,7 +7,7 @@ import (
 	// This import path must match the module path you defined in go.mod.
 	// We import the cli package from the internal folder, which handles
 	// parsing command-line flags and calling the generator.
-	"generate_ldap_entires/internal/cli"
+	"generate_ldap_entries/internal/cli"
 )
 
 // MainConfig is a placeholder struct that could hold global settings
@@ -4,11 +4,12 @@ import (
 	"encoding/json" // json is used to decode JSON input files
 	"fmt"           // fmt is used to create readable error messages
 	"os"            // os is used to open files from disk
+	"time"          // time is used to parse the dial timeout flag
 
 	"github.com/alecthomas/kong" // kong is the library we use to parse command-line flags
 
 	// This import path must match your module path from go.mod.
-	"generate_ldap_entires/internal/generator"
+	"generate_ldap_entries/internal/generator"
 )
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -27,13 +28,40 @@ type CLIConfig struct {
 
 	Mode string `help:"Output mode: 'ldif' to write a file, 'ldap' to add entries to an LDAP server." default:"ldif"`
 
-	LDIFFile string `help:"Path to LDIF file when mode is 'ldif'." default:"fake_users.ldif" name:"ldif-file"`
+	LDIFFile string `help:"Path to LDIF file when mode is 'ldif'. Use '-' for stdout." default:"fake_users.ldif" name:"ldif-file"`
+	Gzip     bool   `help:"Compress LDIF output with gzip as it is streamed, when mode is 'ldif'." name:"gzip"`
 
 	LDAPURL      string `help:"LDAP URL when mode is 'ldap', e.g. 'ldaps://localhost:636'." name:"ldap-url"`
 	BindDN       string `help:"Bind DN for LDAP when mode is 'ldap'." name:"bind-dn"`
 	BindPassword string `help:"Bind password for LDAP when mode is 'ldap'." name:"bind-password"`
 
 	InputFile string `help:"Optional JSON file that provides attribute values (uid, cn, sn, mail). Missing or empty fields are filled with fake data." name:"input-file"`
+
+	SchemaFile string `help:"Optional YAML or JSON file that remaps objectClasses/attribute names and adds extra attributes. Missing fields keep the inetOrgPerson defaults." name:"schema-file"`
+
+	CAFile      string        `help:"PEM file of CA certificates to trust when mode is 'ldap'. Required unless --insecure is set." name:"ca-file"`
+	ClientCert  string        `help:"PEM client certificate for mutual TLS when mode is 'ldap'." name:"client-cert"`
+	ClientKey   string        `help:"PEM private key matching --client-cert." name:"client-key"`
+	ServerName  string        `help:"Server name to use for SNI and certificate verification, if different from the host in --ldap-url." name:"server-name"`
+	Insecure    bool          `help:"Skip LDAP server certificate verification. Required if --ca-file is not set; never do this in production." name:"insecure"`
+	StartTLS    bool          `help:"Dial plain LDAP and upgrade the connection with StartTLS instead of using an ldaps:// URL." name:"start-tls"`
+	DialTimeout time.Duration `help:"Maximum time to wait while dialing the LDAP server." default:"10s" name:"dial-timeout"`
+
+	Parallel        int  `help:"Number of concurrent bound connections to use when mode is 'ldap'." default:"1" name:"parallel"`
+	ContinueOnError bool `help:"Keep adding remaining entries after a permanent per-entry failure, when mode is 'ldap'." name:"continue-on-error"`
+
+	RetryMaxAttempts int           `help:"Maximum attempts to add an entry before giving up, when mode is 'ldap'." default:"3" name:"retry-max-attempts"`
+	RetryBaseDelay   time.Duration `help:"Delay before the first retry after a transient network error, when mode is 'ldap'." default:"500ms" name:"retry-base-delay"`
+	RetryExponential bool          `help:"Double the retry delay after each attempt, when mode is 'ldap'." default:"true" name:"retry-exponential"`
+	RetryJitter      float64       `help:"Fraction of the computed retry delay randomly added on top, e.g. 0.2 = up to 20% extra." default:"0.2" name:"retry-jitter"`
+
+	GroupCount                 int     `help:"Number of group entries to generate alongside the users. 0 disables group generation." default:"0" name:"group-count"`
+	GroupOU                    string  `help:"DN the generated groups (and their parent OU) live under, e.g. 'ou=groups,o=rtx'." name:"group-ou"`
+	GroupNamePattern           string  `help:"fmt pattern for each group's cn, e.g. 'group-%d'." default:"group-%d" name:"group-name-pattern"`
+	GroupObjectClass           string  `help:"objectClass for generated groups: 'groupOfNames', 'groupOfUniqueNames', or 'posixGroup'." default:"groupOfNames" name:"group-object-class"`
+	GroupMinMembers            int     `help:"Lower bound on members per group, when --group-membership-probability is not set." default:"1" name:"group-min-members"`
+	GroupMaxMembers            int     `help:"Upper bound on members per group, when --group-membership-probability is not set." default:"5" name:"group-max-members"`
+	GroupMembershipProbability float64 `help:"If greater than 0, overrides --group-min-members/--group-max-members: each user independently joins every group with this probability." name:"group-membership-probability"`
 }
 
 // NewCLIConfig is an initializer function for CLIConfig.
@@ -41,9 +69,15 @@ type CLIConfig struct {
 // behavior stays consistent.
 func NewCLIConfig() *CLIConfig {
 	return &CLIConfig{
-		Count:    1,
-		Mode:     "ldif",
-		LDIFFile: "fake_users.ldif",
+		Count:            1,
+		Mode:             "ldif",
+		LDIFFile:         "fake_users.ldif",
+		DialTimeout:      10 * time.Second,
+		Parallel:         1,
+		RetryMaxAttempts: 3,
+		RetryBaseDelay:   500 * time.Millisecond,
+		RetryExponential: true,
+		RetryJitter:      0.2,
 	}
 }
 
@@ -117,9 +151,12 @@ func Run() error {
 	runCfg.Count = cfg.Count
 	runCfg.Mode = cfg.Mode
 	runCfg.LDIFFile = cfg.LDIFFile
+	runCfg.Gzip = cfg.Gzip
 	runCfg.LDAPURL = cfg.LDAPURL
 	runCfg.BindDN = cfg.BindDN
 	runCfg.BindPassword = cfg.BindPassword
+	runCfg.Parallel = cfg.Parallel
+	runCfg.ContinueOnError = cfg.ContinueOnError
 
 	// If the user specified an input file, we load it into a template.
 	// Any attributes included in this file will override the generated ones.
@@ -131,12 +168,59 @@ func Run() error {
 		runCfg.Template = tmpl
 	}
 
+	// If the user specified a schema file, load it and use it to remap
+	// objectClasses/attribute names and attach extra attributes.
+	if cfg.SchemaFile != "" {
+		schema, err := generator.LoadSchemaConfig(cfg.SchemaFile)
+		if err != nil {
+			return err
+		}
+		runCfg.Schema = schema
+	}
+
+	// Build the TLS configuration from the corresponding flags. This is only
+	// consulted when Mode == "ldap", but it is harmless to build it always.
+	runCfg.TLSConfig = &generator.TLSConfig{
+		CAFile:             cfg.CAFile,
+		ClientCertFile:     cfg.ClientCert,
+		ClientKeyFile:      cfg.ClientKey,
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.Insecure,
+		StartTLS:           cfg.StartTLS,
+		DialTimeout:        cfg.DialTimeout,
+	}
+
+	// Build the retry policy from the corresponding flags. This is only
+	// consulted when Mode == "ldap", but it is harmless to build it always.
+	runCfg.RetryPolicy = &generator.RetryPolicy{
+		MaxAttempts: cfg.RetryMaxAttempts,
+		BaseDelay:   cfg.RetryBaseDelay,
+		Exponential: cfg.RetryExponential,
+		Jitter:      cfg.RetryJitter,
+	}
+
+	// If the user asked for groups, build a GroupSpec from the --group-*
+	// flags; leaving runCfg.Groups nil (the default) skips group generation
+	// entirely.
+	if cfg.GroupCount > 0 {
+		groups := generator.NewGroupSpec(cfg.GroupOU, cfg.GroupCount)
+		groups.NamePattern = cfg.GroupNamePattern
+		groups.ObjectClass = cfg.GroupObjectClass
+		groups.MinMembers = cfg.GroupMinMembers
+		groups.MaxMembers = cfg.GroupMaxMembers
+		groups.MembershipProbability = cfg.GroupMembershipProbability
+		runCfg.Groups = groups
+	}
+
 	// If the user selected "ldap" mode, we make sure they provided enough
 	// information to actually connect and bind to the LDAP server.
 	if runCfg.Mode == "ldap" {
 		if runCfg.LDAPURL == "" || runCfg.BindDN == "" || runCfg.BindPassword == "" {
 			return fmt.Errorf("mode 'ldap' requires --ldap-url, --bind-dn, and --bind-password")
 		}
+		if !cfg.Insecure && cfg.CAFile == "" {
+			return fmt.Errorf("mode 'ldap' requires --ca-file or the explicit --insecure flag")
+		}
 	}
 
 	// Finally, we hand the fully-populated RunConfig to the generator
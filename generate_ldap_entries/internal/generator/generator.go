@@ -1,13 +1,25 @@
 package generator
 
 import (
-	"crypto/tls" // tls is used to configure secure LDAP connections
-	"fmt"        // fmt is used to build human-readable error messages and strings
-	"os"         // os is used for file operations such as writing LDIF files
+	"bufio"         // bufio buffers the streaming LDIF writer so writes aren't one syscall per entry
+	"compress/gzip" // gzip optionally compresses LDIF output as it is streamed
+	"crypto/tls"    // tls is used to configure secure LDAP connections
+	"crypto/x509"   // x509 is used to build a certificate pool from a CA file
+	"encoding/json" // json is used to parse SchemaConfig files with a .json extension
+	"fmt"           // fmt is used to build human-readable error messages and strings
+	"io"            // io is used for the writer interface shared by stdout and gzip
+	"net"           // net is used to bound how long dialing the LDAP server may take
+	"os"            // os is used for file operations such as writing LDIF files
+	"path/filepath" // filepath is used to pick a parser based on the schema file extension
+	"strconv"       // strconv is used to render counter-style extra attributes
+	"strings"       // strings is used to expand {field} placeholders in extra attributes
+	"sync"          // sync coordinates the writeToLDAP worker pool
+	"time"          // time is used for the dial timeout
 
 	"github.com/brianvoe/gofakeit/v6" // gofakeit generates realistic-looking fake data
 	"github.com/go-ldap/ldap/v3"      // ldap/v3 provides LDAP client and entry types
 	ldif "github.com/go-ldap/ldif"    // ldif converts LDAP entries to LDIF text
+	"gopkg.in/yaml.v3"                // yaml.v3 is used to parse SchemaConfig files with a .yaml/.yml extension
 )
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -19,14 +31,21 @@ import (
 // server. This struct is designed to be independent of the CLI library
 // (Kong) so it can be reused in tests or from other callers.
 type RunConfig struct {
-	SuffixDN     string             // SuffixDN is everything after "uid=<id>,", for example "ou=employee,ou=users,o=rtx"
-	Count        int                // Count is how many fake entries to generate
-	Mode         string             // Mode selects behavior: "ldif" or "ldap"
-	LDIFFile     string             // LDIFFile is the path to write LDIF to when Mode == "ldif"
-	LDAPURL      string             // LDAPURL is the LDAP server URL for Mode == "ldap", e.g. "ldaps://localhost:636"
-	BindDN       string             // BindDN is the DN used to authenticate to the LDAP server
-	BindPassword string             // BindPassword is the password used with BindDN
-	Template     *AttributeTemplate // Template holds optional attribute values loaded from a file
+	SuffixDN        string             // SuffixDN is everything after "uid=<id>,", for example "ou=employee,ou=users,o=rtx"
+	Count           int                // Count is how many fake entries to generate
+	Mode            string             // Mode selects behavior: "ldif" or "ldap"
+	LDIFFile        string             // LDIFFile is the path to write LDIF to when Mode == "ldif"
+	LDAPURL         string             // LDAPURL is the LDAP server URL for Mode == "ldap", e.g. "ldaps://localhost:636"
+	BindDN          string             // BindDN is the DN used to authenticate to the LDAP server
+	BindPassword    string             // BindPassword is the password used with BindDN
+	Template        *AttributeTemplate // Template holds optional attribute values loaded from a file
+	Schema          *SchemaConfig      // Schema remaps objectClasses/attribute names; nil means the inetOrgPerson default
+	TLSConfig       *TLSConfig         // TLSConfig controls certificate verification and StartTLS for Mode == "ldap"
+	Groups          *GroupSpec         // Groups, if set, generates OU and group entries referencing the generated users
+	Gzip            bool               // Gzip, when Mode == "ldif", compresses the output as it is streamed
+	RetryPolicy     *RetryPolicy       // RetryPolicy controls retry/reconnect behavior for Mode == "ldap"; nil means NewRetryPolicy() defaults
+	Parallel        int                // Parallel is how many worker connections writeToLDAP uses for Mode == "ldap"; less than 1 means 1
+	ContinueOnError bool               // ContinueOnError, when Mode == "ldap", keeps adding remaining entries after a permanent failure
 }
 
 // NewRunConfig is an initializer function for RunConfig.
@@ -57,6 +76,279 @@ func NewAttributeTemplate() *AttributeTemplate {
 	return &AttributeTemplate{}
 }
 
+///////////////////////////////////////////////////////////////////////////////
+// Schema configuration
+///////////////////////////////////////////////////////////////////////////////
+
+// ExtraAttribute describes one additional LDAP attribute to attach to every
+// generated entry, beyond the uid/cn/sn/mail set. Expression supports two
+// forms: the literal "counter", which yields the zero-based index of the
+// entry being generated (useful for "uidNumber"/"gidNumber"-style sequential
+// attributes), and a template string containing "{uid}", "{cn}", "{sn}", or
+// "{mail}" placeholders that are substituted with the entry's corresponding
+// value (e.g. "homeDirectory" = "/home/{uid}").
+type ExtraAttribute struct {
+	Name       string `mapstructure:"name" json:"name" yaml:"name"`
+	Expression string `mapstructure:"expression" json:"expression" yaml:"expression"`
+}
+
+// SchemaConfig lets callers remap the objectClasses and attribute names that
+// FakeEntry.ToLDAPEntry hard-codes, plus attach arbitrary extra attributes.
+// This is what lets the generator target schemas other than the default
+// inetOrgPerson one (OpenLDAP's posixAccount, Active Directory's objectGUID,
+// 389 Directory Server, and so on) without recompiling.
+type SchemaConfig struct {
+	ObjectClasses   []string         `mapstructure:"object_classes" json:"object_classes" yaml:"object_classes"`
+	UIDAttribute    string           `mapstructure:"uid_attribute" json:"uid_attribute" yaml:"uid_attribute"`
+	CNAttribute     string           `mapstructure:"cn_attribute" json:"cn_attribute" yaml:"cn_attribute"`
+	SNAttribute     string           `mapstructure:"sn_attribute" json:"sn_attribute" yaml:"sn_attribute"`
+	MailAttribute   string           `mapstructure:"mail_attribute" json:"mail_attribute" yaml:"mail_attribute"`
+	ExtraAttributes []ExtraAttribute `mapstructure:"extra_attributes" json:"extra_attributes" yaml:"extra_attributes"`
+}
+
+// NewSchemaConfig is an initializer function for SchemaConfig. It returns the
+// same objectClass and attribute names the generator has always hard-coded,
+// so passing the result of NewSchemaConfig() behaves exactly like passing
+// nil.
+func NewSchemaConfig() *SchemaConfig {
+	return &SchemaConfig{
+		ObjectClasses: []string{"inetOrgPerson"},
+		UIDAttribute:  "uid",
+		CNAttribute:   "cn",
+		SNAttribute:   "sn",
+		MailAttribute: "mail",
+	}
+}
+
+// LoadSchemaConfig reads a SchemaConfig from a YAML or JSON file, chosen by
+// the file extension (".yaml"/".yml" or ".json"). The config starts from
+// NewSchemaConfig's defaults, so fields the file does not set keep behaving
+// like the built-in inetOrgPerson schema.
+func LoadSchemaConfig(path string) (*SchemaConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema config %q: %w", path, err)
+	}
+
+	cfg := NewSchemaConfig()
+	switch ext := filepath.Ext(path); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML schema config %q: %w", path, err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON schema config %q: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported schema config extension %q (expected .yaml, .yml, or .json)", ext)
+	}
+
+	return cfg, nil
+}
+
+// resolveExtraAttribute expands a single ExtraAttribute's Expression for the
+// given FakeEntry. See ExtraAttribute's doc comment for the supported forms.
+func resolveExtraAttribute(expression string, f *FakeEntry, index int) string {
+	if expression == "counter" {
+		return strconv.Itoa(index)
+	}
+
+	replacer := strings.NewReplacer(
+		"{uid}", f.UID,
+		"{cn}", f.CN,
+		"{sn}", f.SN,
+		"{mail}", f.Mail,
+	)
+	return replacer.Replace(expression)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Group and OU generation
+///////////////////////////////////////////////////////////////////////////////
+
+// GroupSpec describes a set of group entries to generate alongside the user
+// entries, with membership referencing the DNs (or uids, for posixGroup) of
+// the generated users. This turns the generator from "N flat users" into a
+// small directory tree usable for testing authorization, nested groups, and
+// posix login scenarios.
+type GroupSpec struct {
+	Count                 int     `mapstructure:"count" json:"count" yaml:"count"`
+	OU                    string  `mapstructure:"ou" json:"ou" yaml:"ou"`                                           // DN the groups live under, e.g. "ou=groups,o=rtx"
+	NamePattern           string  `mapstructure:"name_pattern" json:"name_pattern" yaml:"name_pattern"`             // fmt pattern for each group's cn, e.g. "group-%d"
+	ObjectClass           string  `mapstructure:"object_class" json:"object_class" yaml:"object_class"`             // "groupOfNames", "groupOfUniqueNames", or "posixGroup"
+	MinMembers            int     `mapstructure:"min_members" json:"min_members" yaml:"min_members"`                // lower bound on members per group
+	MaxMembers            int     `mapstructure:"max_members" json:"max_members" yaml:"max_members"`                // upper bound on members per group
+	MembershipProbability float64 `mapstructure:"membership_probability" json:"membership_probability" yaml:"membership_probability"` // if > 0, overrides Min/MaxMembers: each user independently joins with this probability
+}
+
+// NewGroupSpec is an initializer function for GroupSpec.
+// It sets defaults for a small groupOfNames tree; callers override whatever
+// they need (count, OU, membership shape) before passing it to RunConfig.
+func NewGroupSpec(ou string, count int) *GroupSpec {
+	return &GroupSpec{
+		Count:       count,
+		OU:          ou,
+		NamePattern: "group-%d",
+		ObjectClass: "groupOfNames",
+		MinMembers:  1,
+		MaxMembers:  5,
+	}
+}
+
+// groupMemberAttribute returns the attribute used to list members for a
+// given group objectClass.
+func groupMemberAttribute(objectClass string) (string, error) {
+	switch objectClass {
+	case "groupOfNames":
+		return "member", nil
+	case "groupOfUniqueNames":
+		return "uniqueMember", nil
+	case "posixGroup":
+		return "memberUid", nil
+	default:
+		return "", fmt.Errorf("unsupported group objectClass %q (expected groupOfNames, groupOfUniqueNames, or posixGroup)", objectClass)
+	}
+}
+
+// pickMembers selects which users join a single group, either using a
+// MembershipProbability coin-flip per user, or a random count between
+// MinMembers and MaxMembers drawn from a shuffled copy of users.
+func pickMembers(spec *GroupSpec, users []*FakeEntry) []*FakeEntry {
+	if spec.MembershipProbability > 0 {
+		var members []*FakeEntry
+		for _, u := range users {
+			if gofakeit.Float32Range(0, 1) < float32(spec.MembershipProbability) {
+				members = append(members, u)
+			}
+		}
+		return members
+	}
+
+	count := spec.MinMembers
+	if spec.MaxMembers > spec.MinMembers {
+		count = gofakeit.Number(spec.MinMembers, spec.MaxMembers)
+	}
+	if count > len(users) {
+		count = len(users)
+	}
+
+	// Fisher-Yates shuffle a copy of the user slice so each group gets an
+	// independent, order-shuffled sample of members.
+	shuffled := make([]*FakeEntry, len(users))
+	copy(shuffled, users)
+	for i := len(shuffled) - 1; i > 0; i-- {
+		j := gofakeit.Number(0, i)
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+
+	return shuffled[:count]
+}
+
+// buildGroupEntries generates spec.Count group entries under spec.OU, each
+// with a membership attribute referencing a sample of users. Every group is
+// guaranteed at least one member, since groupOfNames/groupOfUniqueNames
+// require a non-empty member attribute.
+func buildGroupEntries(spec *GroupSpec, users []*FakeEntry) ([]*ldap.Entry, error) {
+	if len(users) == 0 {
+		return nil, fmt.Errorf("cannot generate groups with zero users")
+	}
+
+	memberAttr, err := groupMemberAttribute(spec.ObjectClass)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*ldap.Entry, 0, spec.Count)
+	for i := 0; i < spec.Count; i++ {
+		name := fmt.Sprintf(spec.NamePattern, i)
+		dn := fmt.Sprintf("cn=%s,%s", name, spec.OU)
+
+		members := pickMembers(spec, users)
+		if len(members) == 0 {
+			members = []*FakeEntry{users[gofakeit.Number(0, len(users)-1)]}
+		}
+
+		attrs := map[string][]string{
+			"objectClass": {"top", spec.ObjectClass},
+			"cn":          {name},
+		}
+		if spec.ObjectClass == "posixGroup" {
+			attrs["gidNumber"] = []string{strconv.Itoa(i)}
+			uids := make([]string, 0, len(members))
+			for _, m := range members {
+				uids = append(uids, m.UID)
+			}
+			attrs[memberAttr] = uids
+		} else {
+			dns := make([]string, 0, len(members))
+			for _, m := range members {
+				dns = append(dns, m.DN)
+			}
+			attrs[memberAttr] = dns
+		}
+
+		entries = append(entries, ldap.NewEntry(dn, attrs))
+	}
+
+	return entries, nil
+}
+
+// buildOUEntry returns an organizationalUnit entry for dn, whose first RDN
+// must be "ou=<name>". Emitting these lets a single LDIF/ldapadd pass create
+// the OU containers before the user or group entries that live under them.
+func buildOUEntry(dn string) (*ldap.Entry, error) {
+	rdn, _, found := strings.Cut(dn, ",")
+	if !found {
+		return nil, fmt.Errorf("OU DN %q must include at least one parent component", dn)
+	}
+	name, ok := strings.CutPrefix(rdn, "ou=")
+	if !ok {
+		return nil, fmt.Errorf("OU DN %q must start with an \"ou=\" RDN", dn)
+	}
+
+	attrs := map[string][]string{
+		"objectClass": {"top", "organizationalUnit"},
+		"ou":          {name},
+	}
+	return ldap.NewEntry(dn, attrs), nil
+}
+
+// buildParentOUs returns the organizationalUnit entries needed for the user
+// and group trees this run will create: cfg.SuffixDN and, if groups are
+// configured, cfg.Groups.OU. DNs that do not start with an "ou=" RDN (for
+// example a bare "o=" or "dc=" suffix that is assumed to already exist) are
+// skipped, and duplicate DNs are only emitted once.
+func buildParentOUs(cfg *RunConfig) ([]*ldap.Entry, error) {
+	seen := make(map[string]bool)
+	var entries []*ldap.Entry
+
+	add := func(dn string) error {
+		if dn == "" || seen[dn] || !strings.HasPrefix(dn, "ou=") {
+			return nil
+		}
+		seen[dn] = true
+
+		entry, err := buildOUEntry(dn)
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry)
+		return nil
+	}
+
+	if err := add(cfg.SuffixDN); err != nil {
+		return nil, err
+	}
+	if cfg.Groups != nil {
+		if err := add(cfg.Groups.OU); err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // Fake entry representation
 ///////////////////////////////////////////////////////////////////////////////
@@ -65,11 +357,12 @@ func NewAttributeTemplate() *AttributeTemplate {
 // small so that it is easy to reason about and easy to convert into an
 // *ldap.Entry that the LDAP and LDIF libraries understand.
 type FakeEntry struct {
-	DN   string // DN is the full distinguished name, for example "uid=jdoe,ou=employee,ou=users,o=rtx"
-	UID  string // UID will become the "uid" attribute in LDAP
-	CN   string // CN is the common name, for example "John Doe"
-	SN   string // SN is the surname / last name, for example "Doe"
-	Mail string // Mail is the email address
+	DN    string              // DN is the full distinguished name, for example "uid=jdoe,ou=employee,ou=users,o=rtx"
+	UID   string              // UID will become the "uid" attribute in LDAP
+	CN    string              // CN is the common name, for example "John Doe"
+	SN    string              // SN is the surname / last name, for example "Doe"
+	Mail  string              // Mail is the email address
+	Extra map[string][]string // Extra holds schema-defined attributes (e.g. uidNumber, homeDirectory) keyed by attribute name
 }
 
 // NewFakeEntry is an initializer function for FakeEntry.
@@ -90,8 +383,10 @@ func NewFakeEntry(dn, uid, cn, sn, mail string) *FakeEntry {
 //
 // suffixDN should be everything after "uid=<id>,", for example
 // "ou=employee,ou=users,o=rtx". tmpl can be nil, in which case all
-// attributes are generated.
-func NewFakeEntryWithTemplate(suffixDN string, tmpl *AttributeTemplate) *FakeEntry {
+// attributes are generated. schema can be nil, in which case no extra
+// attributes are attached. index is this entry's zero-based position in the
+// run, used to resolve "counter"-style extra attributes such as uidNumber.
+func NewFakeEntryWithTemplate(suffixDN string, tmpl *AttributeTemplate, schema *SchemaConfig, index int) *FakeEntry {
 	// Generate baseline fake values for the different pieces.
 	// We do this first so we always have values to fall back on.
 	first := gofakeit.FirstName() // random first name
@@ -129,7 +424,19 @@ func NewFakeEntryWithTemplate(suffixDN string, tmpl *AttributeTemplate) *FakeEnt
 	dn := fmt.Sprintf("uid=%s,%s", uid, suffixDN)
 
 	// Use the explicit initializer so construction is obvious and consistent.
-	return NewFakeEntry(dn, uid, cn, last, email)
+	fake := NewFakeEntry(dn, uid, cn, last, email)
+
+	// If the schema defines extra attributes (uidNumber, homeDirectory,
+	// objectGUID, ...), resolve each expression now while we still have the
+	// entry's index handy, and stash the results on the entry itself.
+	if schema != nil && len(schema.ExtraAttributes) > 0 {
+		fake.Extra = make(map[string][]string, len(schema.ExtraAttributes))
+		for _, extra := range schema.ExtraAttributes {
+			fake.Extra[extra.Name] = []string{resolveExtraAttribute(extra.Expression, fake, index)}
+		}
+	}
+
+	return fake
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -140,13 +447,26 @@ func NewFakeEntryWithTemplate(suffixDN string, tmpl *AttributeTemplate) *FakeEnt
 // because the LDIF library and the LDAP client library both expect
 // *ldap.Entry values rather than custom structs. By keeping the conversion
 // here, changes to attributes only have to be made once.
-func (f *FakeEntry) ToLDAPEntry() *ldap.Entry {
+//
+// schema controls which objectClasses and attribute names are used; pass nil
+// to get the built-in inetOrgPerson defaults.
+func (f *FakeEntry) ToLDAPEntry(schema *SchemaConfig) *ldap.Entry {
+	if schema == nil {
+		schema = NewSchemaConfig()
+	}
+
 	attrs := map[string][]string{
-		"objectClass": {"inetOrgPerson"}, // inetOrgPerson is a common objectClass for user entries
-		"uid":         {f.UID},           // uid attribute
-		"cn":          {f.CN},            // cn attribute
-		"sn":          {f.SN},            // sn attribute
-		"mail":        {f.Mail},          // mail attribute
+		"objectClass":        schema.ObjectClasses, // objectClasses to attach, e.g. top/person/inetOrgPerson/posixAccount
+		schema.UIDAttribute:  {f.UID},               // attribute that carries the uid value
+		schema.CNAttribute:   {f.CN},                // attribute that carries the cn value
+		schema.SNAttribute:   {f.SN},                // attribute that carries the sn value
+		schema.MailAttribute: {f.Mail},               // attribute that carries the mail value
+	}
+
+	// Schema-defined extras (uidNumber, homeDirectory, objectGUID, ...) were
+	// already resolved in NewFakeEntryWithTemplate; just copy them in.
+	for name, values := range f.Extra {
+		attrs[name] = values
 	}
 
 	return ldap.NewEntry(f.DN, attrs)
@@ -156,93 +476,425 @@ func (f *FakeEntry) ToLDAPEntry() *ldap.Entry {
 // LDIF writing
 ///////////////////////////////////////////////////////////////////////////////
 
-// writeLDIFFile takes a set of *ldap.Entry values and writes them into an
-// LDIF file whose path is specified in cfg.LDIFFile. It uses the ldif
-// package to convert the entries into proper LDIF text.
-func writeLDIFFile(cfg *RunConfig, entries []*ldap.Entry) error {
-	// ldif.ToLDIF wraps the entries into a structure that ldif.Marshal
-	// knows how to convert into a string.
-	ldifData, err := ldif.ToLDIF(entries)
+// LDIFStreamWriter writes one LDIF record per entry as it is generated,
+// instead of building every entry in memory, calling ldif.ToLDIF once, and
+// marshaling the whole thing in a single string. That made `--count
+// 1000000` blow up memory; streaming keeps memory bounded regardless of how
+// many entries are written.
+type LDIFStreamWriter struct {
+	file   *os.File     // file is nil when writing to stdout
+	gz     *gzip.Writer // gz is nil unless gzip compression was requested
+	bw     *bufio.Writer
+	closed bool
+}
+
+// NewLDIFStreamWriter opens path for writing, or uses stdout when path is
+// "-", and returns a writer ready to stream LDIF records to it. When gzipOut
+// is true, the output is wrapped in a gzip.Writer so the destination is
+// compressed as entries are written rather than after the fact.
+func NewLDIFStreamWriter(path string, gzipOut bool) (*LDIFStreamWriter, error) {
+	var file *os.File
+	var out io.Writer = os.Stdout
+
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open LDIF output %q: %w", path, err)
+		}
+		file = f
+		out = f
+	}
+
+	w := &LDIFStreamWriter{file: file}
+
+	if gzipOut {
+		w.gz = gzip.NewWriter(out)
+		out = w.gz
+	}
+
+	w.bw = bufio.NewWriter(out)
+	return w, nil
+}
+
+// WriteEntry marshals a single *ldap.Entry into an LDIF record and writes
+// it, relying on the underlying bufio.Writer to flush periodically instead
+// of accumulating output in memory.
+func (w *LDIFStreamWriter) WriteEntry(entry *ldap.Entry) error {
+	ldifData, err := ldif.ToLDIF([]*ldap.Entry{entry})
 	if err != nil {
-		return fmt.Errorf("failed to build LDIF struct: %w", err)
+		return fmt.Errorf("failed to build LDIF record for %s: %w", entry.DN, err)
 	}
 
-	// ldif.Marshal turns the LDIF structure into a text LDIF string that
-	// can be written to a file or printed on screen.
-	ldifText, err := ldif.Marshal(ldifData)
+	text, err := ldif.Marshal(ldifData)
 	if err != nil {
-		return fmt.Errorf("failed to marshal LDIF: %w", err)
+		return fmt.Errorf("failed to marshal LDIF record for %s: %w", entry.DN, err)
 	}
 
-	// Write the LDIF string to disk. The permission 0644 means the owner
-	// can read and write, while group and others can only read.
-	if err := os.WriteFile(cfg.LDIFFile, []byte(ldifText), 0o644); err != nil {
-		return fmt.Errorf("failed to write LDIF file: %w", err)
+	if _, err := w.bw.WriteString(text); err != nil {
+		return fmt.Errorf("failed to write LDIF record for %s: %w", entry.DN, err)
+	}
+
+	return nil
+}
+
+// Close flushes any buffered output, closes the gzip writer if one was used,
+// and closes the destination file. It is safe to call more than once, and
+// it never closes stdout.
+func (w *LDIFStreamWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if err := w.bw.Flush(); err != nil {
+		return fmt.Errorf("failed to flush LDIF output: %w", err)
+	}
+	if w.gz != nil {
+		if err := w.gz.Close(); err != nil {
+			return fmt.Errorf("failed to close gzip writer: %w", err)
+		}
+	}
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return fmt.Errorf("failed to close LDIF output file: %w", err)
+		}
 	}
 
 	return nil
 }
 
+///////////////////////////////////////////////////////////////////////////////
+// TLS configuration
+///////////////////////////////////////////////////////////////////////////////
+
+// TLSConfig controls how writeToLDAP secures its connection to the LDAP
+// server. It covers both ldaps:// (TLS from the first byte) and StartTLS
+// (plain LDAP on port 389, upgraded in place via the StartTLS extended
+// operation), matching the two upgrade paths go-ldap exposes.
+type TLSConfig struct {
+	CAFile             string        // CAFile is a PEM bundle of CA certificates to trust; required unless InsecureSkipVerify is set
+	ClientCertFile     string        // ClientCertFile is a PEM client certificate for mutual TLS; optional
+	ClientKeyFile      string        // ClientKeyFile is the PEM private key matching ClientCertFile; optional
+	ServerName         string        // ServerName overrides the name used for SNI and certificate verification
+	InsecureSkipVerify bool          // InsecureSkipVerify disables certificate verification; must be set explicitly, never the default
+	StartTLS           bool          // StartTLS dials plain LDAP and upgrades with StartTLS instead of dialing ldaps://
+	DialTimeout        time.Duration // DialTimeout bounds how long dialing the server may take before giving up
+}
+
+// NewTLSConfig is an initializer function for TLSConfig.
+// It sets a sane dial timeout so a hung server cannot wedge the process;
+// every other field defaults to "verify certificates against CAFile", which
+// forces the caller to set a CA or explicitly opt into InsecureSkipVerify.
+func NewTLSConfig() *TLSConfig {
+	return &TLSConfig{
+		DialTimeout: 10 * time.Second,
+	}
+}
+
+// buildTLSConfig turns a TLSConfig into a *tls.Config, loading the CA bundle
+// and client certificate from disk when configured. It refuses to proceed if
+// certificate verification has not been explicitly disabled and no CA was
+// supplied, rather than falling back to the host's root CA set.
+func buildTLSConfig(tlsCfg *TLSConfig) (*tls.Config, error) {
+	if !tlsCfg.InsecureSkipVerify && tlsCfg.CAFile == "" {
+		return nil, fmt.Errorf("refusing to connect without certificate verification: set TLSConfig.CAFile or explicitly opt into TLSConfig.InsecureSkipVerify")
+	}
+
+	conf := &tls.Config{
+		ServerName:         tlsCfg.ServerName,
+		InsecureSkipVerify: tlsCfg.InsecureSkipVerify,
+	}
+
+	if tlsCfg.CAFile != "" {
+		caCert, err := os.ReadFile(tlsCfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file %q: %w", tlsCfg.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("failed to parse any certificates from CA file %q", tlsCfg.CAFile)
+		}
+		conf.RootCAs = pool
+	}
+
+	if tlsCfg.ClientCertFile != "" || tlsCfg.ClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(tlsCfg.ClientCertFile, tlsCfg.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate/key: %w", err)
+		}
+		conf.Certificates = []tls.Certificate{cert}
+	}
+
+	return conf, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+// Retry policy and per-entry outcomes
+///////////////////////////////////////////////////////////////////////////////
+
+// RetryPolicy controls how writeToLDAP retries a transient network failure
+// while adding an entry: how many attempts to make, the base delay between
+// them, and whether that delay grows exponentially with jitter.
+type RetryPolicy struct {
+	MaxAttempts int           `mapstructure:"max_attempts" json:"max_attempts" yaml:"max_attempts"`
+	BaseDelay   time.Duration `mapstructure:"base_delay" json:"base_delay" yaml:"base_delay"`
+	Exponential bool          `mapstructure:"exponential" json:"exponential" yaml:"exponential"`
+	Jitter      float64       `mapstructure:"jitter" json:"jitter" yaml:"jitter"` // fraction of the computed delay randomly added on top, e.g. 0.2 = up to 20% extra
+}
+
+// NewRetryPolicy is an initializer function for RetryPolicy.
+// It sets a small, exponential-with-jitter default: three attempts starting
+// at half a second.
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		Exponential: true,
+		Jitter:      0.2,
+	}
+}
+
+// delay returns how long to wait before the retry following the given
+// zero-based attempt number.
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	d := p.BaseDelay
+	if p.Exponential {
+		d = p.BaseDelay * time.Duration(1<<uint(attempt))
+	}
+	if p.Jitter > 0 {
+		d += time.Duration(float64(d) * p.Jitter * float64(gofakeit.Float32Range(0, 1)))
+	}
+	return d
+}
+
+// EntryStatus classifies what happened when writeToLDAP tried to add a
+// single entry.
+type EntryStatus int
+
+const (
+	EntryAdded          EntryStatus = iota // EntryAdded means the Add request succeeded
+	EntrySkippedExists                     // EntrySkippedExists means the server reported the DN already exists
+	EntryFailed                            // EntryFailed means every attempt failed
+)
+
+// String renders an EntryStatus the way it is reported to the user.
+func (s EntryStatus) String() string {
+	switch s {
+	case EntryAdded:
+		return "added"
+	case EntrySkippedExists:
+		return "skipped-because-exists"
+	case EntryFailed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// EntryResult records the outcome of adding a single entry to LDAP.
+type EntryResult struct {
+	DN     string      // DN of the entry the result is for
+	Status EntryStatus // Status this entry ended up in
+	Err    error       // Err is set when Status == EntryFailed
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 // LDAP writing
 ///////////////////////////////////////////////////////////////////////////////
 
-// writeToLDAP connects to an LDAP server using the information in RunConfig,
-// then sends Add requests for each entry. This function is intentionally
-// simple and meant for test/demo purposes rather than production.
-//
-// In a real environment, you would:
-//   - validate TLS certificates instead of skipping verification,
-//   - handle errors more gracefully,
-//   - avoid passing plain text passwords around.
-func writeToLDAP(cfg *RunConfig, entries []*ldap.Entry) error {
-	// Dial the LDAP server using the URL from the config.
-	// For LDAPS, use a URL like "ldaps://localhost:636".
-	l, err := ldap.DialURL(cfg.LDAPURL, ldap.DialWithTLSConfig(&tls.Config{
-		InsecureSkipVerify: true, // WARNING: For testing only. Do not use in production.
-	}))
-	if err != nil {
-		return fmt.Errorf("failed to connect to LDAP server: %w", err)
+// dialAndBind opens a new LDAP connection using cfg's URL and TLS settings
+// and binds as cfg.BindDN. Both the worker pool in writeToLDAP and its
+// reconnect-on-network-error path call this, so every connection is set up
+// identically.
+func dialAndBind(cfg *RunConfig) (*ldap.Conn, error) {
+	tlsCfg := cfg.TLSConfig
+	if tlsCfg == nil {
+		tlsCfg = NewTLSConfig()
+	}
+
+	dialOpts := []ldap.DialOpt{
+		ldap.DialWithDialer(&net.Dialer{Timeout: tlsCfg.DialTimeout}),
+	}
+
+	var l *ldap.Conn
+	if tlsCfg.StartTLS {
+		// Dial plain LDAP first; the connection is upgraded to TLS below via
+		// an explicit StartTLS call rather than at dial time.
+		conn, err := ldap.DialURL(cfg.LDAPURL, dialOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to LDAP server: %w", err)
+		}
+		l = conn
+
+		tlsConf, err := buildTLSConfig(tlsCfg)
+		if err != nil {
+			l.Close()
+			return nil, err
+		}
+		if err := l.StartTLS(tlsConf); err != nil {
+			l.Close()
+			return nil, fmt.Errorf("failed to start TLS: %w", err)
+		}
+	} else {
+		tlsConf, err := buildTLSConfig(tlsCfg)
+		if err != nil {
+			return nil, err
+		}
+
+		conn, err := ldap.DialURL(cfg.LDAPURL, append(dialOpts, ldap.DialWithTLSConfig(tlsConf))...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to LDAP server: %w", err)
+		}
+		l = conn
 	}
-	// Ensure the connection is closed when we are done so resources
-	// are not leaked.
-	defer l.Close()
 
 	// Authenticate (bind) to the server using the provided BindDN and
 	// BindPassword so the server knows who we are.
 	if err := l.Bind(cfg.BindDN, cfg.BindPassword); err != nil {
-		return fmt.Errorf("failed to bind to LDAP server: %w", err)
+		l.Close()
+		return nil, fmt.Errorf("failed to bind to LDAP server: %w", err)
 	}
 
-	// Loop over each entry and send an Add request for it.
-	for _, e := range entries {
-		req := ldap.NewAddRequest(e.DN, nil)
+	return l, nil
+}
+
+// addEntryWithRetry sends an Add request for entry over *conn, retrying
+// according to retry whenever the server reports a network error: it closes
+// the stale connection, reconnects and rebinds via dialAndBind (the pattern
+// used by projects like panettone), and tries again. A "the DN already
+// exists" response is reported as EntrySkippedExists rather than a failure.
+func addEntryWithRetry(cfg *RunConfig, conn **ldap.Conn, entry *ldap.Entry, retry *RetryPolicy) (EntryStatus, error) {
+	req := ldap.NewAddRequest(entry.DN, nil)
+	for _, attr := range entry.Attributes {
+		req.Attribute(attr.Name, attr.Values)
+	}
 
-		// Copy all attributes from the ldap.Entry into the AddRequest.
-		for _, attr := range e.Attributes {
-			req.Attribute(attr.Name, attr.Values)
+	var lastErr error
+	for attempt := 0; attempt < retry.MaxAttempts; attempt++ {
+		err := (*conn).Add(req)
+		if err == nil {
+			return EntryAdded, nil
+		}
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultEntryAlreadyExists) {
+			return EntrySkippedExists, nil
 		}
 
-		// Send the Add request to the server.
-		if err := l.Add(req); err != nil {
-			return fmt.Errorf("failed to add entry %s: %w", e.DN, err)
+		lastErr = err
+		if !ldap.IsErrorWithCode(err, ldap.ErrorNetwork) {
+			break // Not transient; retrying would not help.
+		}
+
+		(*conn).Close()
+		newConn, dialErr := dialAndBind(cfg)
+		if dialErr != nil {
+			lastErr = dialErr
+			break
+		}
+		*conn = newConn
+
+		if attempt < retry.MaxAttempts-1 {
+			time.Sleep(retry.delay(attempt))
 		}
 	}
 
-	return nil
+	return EntryFailed, fmt.Errorf("failed to add entry %s: %w", entry.DN, lastErr)
+}
+
+// writeToLDAP connects to an LDAP server using the information in RunConfig
+// and sends Add requests for each entry, using up to cfg.Parallel worker
+// connections. It returns one EntryResult per entry it attempted, whatever
+// the outcome; entries never attempted (after an abort, or when a worker
+// fails to dial) get no result. When cfg.ContinueOnError is false, the first
+// permanent failure stops the remaining work and is also returned as an
+// error.
+func writeToLDAP(cfg *RunConfig, entries []*ldap.Entry) ([]EntryResult, error) {
+	retry := cfg.RetryPolicy
+	if retry == nil {
+		retry = NewRetryPolicy()
+	}
+
+	parallel := cfg.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	jobs := make(chan *ldap.Entry)
+	var (
+		results   []EntryResult
+		resultsMu sync.Mutex
+	)
+
+	abort := make(chan struct{})
+	var abortOnce sync.Once
+	triggerAbort := func() { abortOnce.Do(func() { close(abort) }) }
+
+	dialErrs := make(chan error, parallel)
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			conn, err := dialAndBind(cfg)
+			if err != nil {
+				dialErrs <- err
+				triggerAbort()
+				return
+			}
+			defer conn.Close()
+
+			for entry := range jobs {
+				status, addErr := addEntryWithRetry(cfg, &conn, entry, retry)
+
+				resultsMu.Lock()
+				results = append(results, EntryResult{DN: entry.DN, Status: status, Err: addErr})
+				resultsMu.Unlock()
+
+				if status == EntryFailed && !cfg.ContinueOnError {
+					triggerAbort()
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, entry := range entries {
+		select {
+		case jobs <- entry:
+		case <-abort:
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	select {
+	case err := <-dialErrs:
+		return results, err
+	default:
+	}
+
+	if !cfg.ContinueOnError {
+		for _, r := range results {
+			if r.Status == EntryFailed {
+				return results, r.Err
+			}
+		}
+	}
+
+	return results, nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////
 // Top-level runner
 ///////////////////////////////////////////////////////////////////////////////
 
-// Run is the main entry point for this package. It:
-//
-//  1. Validates the provided configuration.
-//  2. Seeds the fake data generator.
-//  3. Generates cfg.Count FakeEntry values, using cfg.Template if present.
-//  4. Converts them into *ldap.Entry values.
-//  5. Either writes an LDIF file or sends them to LDAP, based on cfg.Mode.
+// Run is the main entry point for this package. It validates the provided
+// configuration, seeds the fake data generator, and then delegates to
+// runLDIF or runLDAP depending on cfg.Mode. Both generate cfg.Count
+// FakeEntry values (using cfg.Template and cfg.Schema if present), plus any
+// OU/group entries cfg.Groups describes, in dependency order.
 //
 // This function does not depend on Kong or any CLI library; it only uses
 // the RunConfig struct. That makes it easier to test and reuse.
@@ -265,23 +917,129 @@ func Run(cfg *RunConfig) error {
 	// time.Now().UnixNano().
 	gofakeit.Seed(0)
 
-	// Generate the requested number of entries and convert them into
-	// ldap.Entry values so they can be written to LDIF or LDAP.
-	var ldapEntries []*ldap.Entry
-	for i := 0; i < cfg.Count; i++ {
-		fake := NewFakeEntryWithTemplate(cfg.SuffixDN, cfg.Template)
-		ldapEntries = append(ldapEntries, fake.ToLDAPEntry())
-	}
-
 	// Decide what to do with the generated entries based on the Mode.
 	switch cfg.Mode {
 	case "ldif":
-		return writeLDIFFile(cfg, ldapEntries)
+		return runLDIF(cfg)
 	case "ldap":
-		return writeToLDAP(cfg, ldapEntries)
+		return runLDAP(cfg)
 	default:
 		// This should never be reached because we validate Mode above,
 		// but we keep it as a safety net.
 		return fmt.Errorf("unsupported mode: %s", cfg.Mode)
 	}
 }
+
+// runLDIF generates cfg.Count entries (plus any OU/group entries) and
+// streams them to cfg.LDIFFile one LDIF record at a time via
+// LDIFStreamWriter, rather than holding every *ldap.Entry in memory at once.
+// Group membership still requires the full set of generated FakeEntry
+// values, so that (much smaller) slice is kept around only when cfg.Groups
+// is set.
+func runLDIF(cfg *RunConfig) error {
+	w, err := NewLDIFStreamWriter(cfg.LDIFFile, cfg.Gzip)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	// Emit the OU containers first so a single LDIF/ldapadd pass creates
+	// parents before anything that lives under them.
+	parentOUs, err := buildParentOUs(cfg)
+	if err != nil {
+		return err
+	}
+	for _, ou := range parentOUs {
+		if err := w.WriteEntry(ou); err != nil {
+			return err
+		}
+	}
+
+	var fakes []*FakeEntry
+	if cfg.Groups != nil {
+		fakes = make([]*FakeEntry, 0, cfg.Count)
+	}
+
+	for i := 0; i < cfg.Count; i++ {
+		fake := NewFakeEntryWithTemplate(cfg.SuffixDN, cfg.Template, cfg.Schema, i)
+		if cfg.Groups != nil {
+			fakes = append(fakes, fake)
+		}
+		if err := w.WriteEntry(fake.ToLDAPEntry(cfg.Schema)); err != nil {
+			return err
+		}
+	}
+
+	// Groups are emitted last since their member/uniqueMember/memberUid
+	// attributes reference the users generated above.
+	if cfg.Groups != nil {
+		groupEntries, err := buildGroupEntries(cfg.Groups, fakes)
+		if err != nil {
+			return err
+		}
+		for _, g := range groupEntries {
+			if err := w.WriteEntry(g); err != nil {
+				return err
+			}
+		}
+	}
+
+	return w.Close()
+}
+
+// runLDAP generates cfg.Count entries (plus any OU/group entries) and sends
+// them to the LDAP server described by cfg via writeToLDAP.
+func runLDAP(cfg *RunConfig) error {
+	var ldapEntries []*ldap.Entry
+
+	parentOUs, err := buildParentOUs(cfg)
+	if err != nil {
+		return err
+	}
+	ldapEntries = append(ldapEntries, parentOUs...)
+
+	fakes := make([]*FakeEntry, 0, cfg.Count)
+	for i := 0; i < cfg.Count; i++ {
+		fake := NewFakeEntryWithTemplate(cfg.SuffixDN, cfg.Template, cfg.Schema, i)
+		fakes = append(fakes, fake)
+		ldapEntries = append(ldapEntries, fake.ToLDAPEntry(cfg.Schema))
+	}
+
+	if cfg.Groups != nil {
+		groupEntries, err := buildGroupEntries(cfg.Groups, fakes)
+		if err != nil {
+			return err
+		}
+		ldapEntries = append(ldapEntries, groupEntries...)
+	}
+
+	results, err := writeToLDAP(cfg, ldapEntries)
+	reportEntryResults(results)
+	return err
+}
+
+// reportEntryResults prints a one-line summary of how many entries landed in
+// each EntryStatus, followed by the DN and error for every EntryFailed
+// result, so a --continue-on-error run still tells the operator exactly
+// which DNs need to be retried by hand.
+func reportEntryResults(results []EntryResult) {
+	var added, skipped, failed int
+	for _, r := range results {
+		switch r.Status {
+		case EntryAdded:
+			added++
+		case EntrySkippedExists:
+			skipped++
+		case EntryFailed:
+			failed++
+		}
+	}
+
+	fmt.Printf("ldap: %d added, %d skipped-because-exists, %d failed (of %d)\n", added, skipped, failed, len(results))
+
+	for _, r := range results {
+		if r.Status == EntryFailed {
+			fmt.Printf("  failed: %s: %v\n", r.DN, r.Err)
+		}
+	}
+}
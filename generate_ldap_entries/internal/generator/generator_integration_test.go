@@ -0,0 +1,140 @@
+//go:build integration
+
+package generator_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/testcontainers/testcontainers-go/modules/openldap"
+
+	"generate_ldap_entries/internal/generator"
+)
+
+const (
+	testAdminPassword = "adminpassword"
+	testSuffixDN      = "ou=people,dc=example,dc=org"
+)
+
+// startOpenLDAP spins up a bitnami/openldap container for the duration of
+// the test, returning the container (so callers can use its LoadLdif
+// capability) and a bound *ldap.Conn for Search assertions.
+func startOpenLDAP(t *testing.T) (*openldap.OpenLDAPContainer, *ldap.Conn) {
+	t.Helper()
+
+	ctx := context.Background()
+
+	container, err := openldap.Run(ctx, "bitnami/openldap:2.6",
+		openldap.WithAdminUsername("admin"),
+		openldap.WithAdminPassword(testAdminPassword),
+		openldap.WithRoot("dc=example,dc=org"),
+	)
+	if err != nil {
+		t.Fatalf("failed to start openldap container: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Errorf("failed to terminate openldap container: %v", err)
+		}
+	})
+
+	connStr, err := container.ConnectionString(ctx)
+	if err != nil {
+		t.Fatalf("failed to get connection string: %v", err)
+	}
+
+	conn, err := ldap.DialURL(connStr)
+	if err != nil {
+		t.Fatalf("failed to dial openldap container: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if err := conn.Bind(fmt.Sprintf("cn=admin,%s", "dc=example,dc=org"), testAdminPassword); err != nil {
+		t.Fatalf("failed to bind to openldap container: %v", err)
+	}
+
+	return container, conn
+}
+
+// searchCount runs a subtree search under suffixDN and returns how many
+// entries matched, so tests can assert on exactly how many were added.
+func searchCount(t *testing.T, conn *ldap.Conn, suffixDN string) int {
+	t.Helper()
+
+	res, err := conn.Search(ldap.NewSearchRequest(
+		suffixDN,
+		ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=inetOrgPerson)",
+		[]string{"uid", "cn", "sn", "mail"},
+		nil,
+	))
+	if err != nil {
+		t.Fatalf("failed to search %q: %v", suffixDN, err)
+	}
+
+	return len(res.Entries)
+}
+
+// TestRunLDAPModeAddsExpectedEntries exercises the full "ldap" mode path:
+// generator.Run dials the container directly and adds cfg.Count entries,
+// which are then verified over a fresh connection via Search.
+func TestRunLDAPModeAddsExpectedEntries(t *testing.T) {
+	container, conn := startOpenLDAP(t)
+	connStr, err := container.ConnectionString(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get connection string: %v", err)
+	}
+
+	cfg := generator.NewRunConfig()
+	cfg.SuffixDN = testSuffixDN
+	cfg.Mode = "ldap"
+	cfg.Count = 5
+	cfg.LDAPURL = connStr
+	cfg.BindDN = fmt.Sprintf("cn=admin,%s", "dc=example,dc=org")
+	cfg.BindPassword = testAdminPassword
+	cfg.TLSConfig = &generator.TLSConfig{InsecureSkipVerify: true}
+
+	if err := generator.Run(cfg); err != nil {
+		t.Fatalf("generator.Run failed: %v", err)
+	}
+
+	if got := searchCount(t, conn, testSuffixDN); got != cfg.Count {
+		t.Fatalf("expected %d entries under %q, found %d", cfg.Count, testSuffixDN, got)
+	}
+}
+
+// TestRunLDIFModeRoundTripsThroughOpenLDAP generates an LDIF file with Run
+// in "ldif" mode, loads it into a fresh container via LoadLdif, and then
+// searches to make sure the round trip produced valid, attribute-complete
+// entries (catching attribute-ordering, encoding, and schema-mismatch bugs
+// that the unit tests in generator_test.go can't see).
+func TestRunLDIFModeRoundTripsThroughOpenLDAP(t *testing.T) {
+	container, conn := startOpenLDAP(t)
+
+	ldifFile, err := os.CreateTemp(t.TempDir(), "fake_users-*.ldif")
+	if err != nil {
+		t.Fatalf("failed to create temp LDIF file: %v", err)
+	}
+	ldifFile.Close()
+
+	cfg := generator.NewRunConfig()
+	cfg.SuffixDN = testSuffixDN
+	cfg.Mode = "ldif"
+	cfg.Count = 5
+	cfg.LDIFFile = ldifFile.Name()
+
+	if err := generator.Run(cfg); err != nil {
+		t.Fatalf("generator.Run failed: %v", err)
+	}
+
+	if err := container.LoadLdif(context.Background(), ldifFile.Name()); err != nil {
+		t.Fatalf("failed to load generated LDIF into openldap: %v", err)
+	}
+
+	if got := searchCount(t, conn, testSuffixDN); got != cfg.Count {
+		t.Fatalf("expected %d entries under %q after LDIF load, found %d", cfg.Count, testSuffixDN, got)
+	}
+}
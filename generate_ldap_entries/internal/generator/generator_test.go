@@ -0,0 +1,431 @@
+package generator
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := &RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, Exponential: true, Jitter: 0}
+
+	for attempt, want := range map[int]time.Duration{
+		0: 100 * time.Millisecond,
+		1: 200 * time.Millisecond,
+		2: 400 * time.Millisecond,
+		3: 800 * time.Millisecond,
+	} {
+		if got := p.delay(attempt); got != want {
+			t.Errorf("delay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+}
+
+func TestRetryPolicyDelayNonExponential(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: 250 * time.Millisecond, Exponential: false, Jitter: 0}
+
+	for _, attempt := range []int{0, 1, 4} {
+		if got := p.delay(attempt); got != 250*time.Millisecond {
+			t.Errorf("delay(%d) = %v, want 250ms (non-exponential)", attempt, got)
+		}
+	}
+}
+
+func TestRetryPolicyDelayJitterStaysWithinBounds(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: 100 * time.Millisecond, Exponential: false, Jitter: 0.2}
+
+	for i := 0; i < 50; i++ {
+		d := p.delay(0)
+		if d < 100*time.Millisecond || d > 120*time.Millisecond {
+			t.Fatalf("delay() = %v, want within [100ms, 120ms] for Jitter 0.2", d)
+		}
+	}
+}
+
+func newTestUsers(n int) []*FakeEntry {
+	users := make([]*FakeEntry, n)
+	for i := range users {
+		users[i] = NewFakeEntry("uid=u"+string(rune('a'+i))+",ou=people,o=rtx", "u"+string(rune('a'+i)), "", "", "")
+	}
+	return users
+}
+
+func TestPickMembersMembershipProbabilityZeroFallsBackToMinMax(t *testing.T) {
+	spec := NewGroupSpec("ou=groups,o=rtx", 1)
+	spec.MinMembers, spec.MaxMembers = 3, 3
+	spec.MembershipProbability = 0
+
+	members := pickMembers(spec, newTestUsers(10))
+	if len(members) != 3 {
+		t.Fatalf("expected 3 members from MinMembers==MaxMembers==3, got %d", len(members))
+	}
+}
+
+func TestPickMembersMembershipProbabilityOneSelectsEveryUser(t *testing.T) {
+	spec := NewGroupSpec("ou=groups,o=rtx", 1)
+	spec.MembershipProbability = 1
+
+	users := newTestUsers(10)
+	members := pickMembers(spec, users)
+	if len(members) != len(users) {
+		t.Fatalf("expected all %d users to join with MembershipProbability 1, got %d", len(users), len(members))
+	}
+}
+
+func TestPickMembersMinMaxNeverExceedsUserCount(t *testing.T) {
+	spec := NewGroupSpec("ou=groups,o=rtx", 1)
+	spec.MinMembers, spec.MaxMembers = 1, 20
+
+	members := pickMembers(spec, newTestUsers(3))
+	if len(members) > 3 {
+		t.Fatalf("expected at most 3 members when only 3 users exist, got %d", len(members))
+	}
+}
+
+func TestBuildGroupEntriesRejectsZeroUsers(t *testing.T) {
+	spec := NewGroupSpec("ou=groups,o=rtx", 1)
+
+	if _, err := buildGroupEntries(spec, nil); err == nil {
+		t.Fatal("expected an error when no users are available to populate group membership")
+	}
+}
+
+func TestBuildGroupEntriesGroupOfNamesUsesMemberDNs(t *testing.T) {
+	spec := NewGroupSpec("ou=groups,o=rtx", 2)
+	users := newTestUsers(5)
+
+	entries, err := buildGroupEntries(spec, users)
+	if err != nil {
+		t.Fatalf("buildGroupEntries failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 group entries, got %d", len(entries))
+	}
+
+	for _, e := range entries {
+		members := e.GetAttributeValues("member")
+		if len(members) == 0 {
+			t.Errorf("group %s has no members", e.DN)
+		}
+		for _, m := range members {
+			if !strings.HasPrefix(m, "uid=") {
+				t.Errorf("group %s member %q does not look like a user DN", e.DN, m)
+			}
+		}
+	}
+}
+
+func TestBuildGroupEntriesPosixGroupUsesMemberUIDs(t *testing.T) {
+	spec := NewGroupSpec("ou=groups,o=rtx", 1)
+	spec.ObjectClass = "posixGroup"
+	users := newTestUsers(5)
+
+	entries, err := buildGroupEntries(spec, users)
+	if err != nil {
+		t.Fatalf("buildGroupEntries failed: %v", err)
+	}
+
+	e := entries[0]
+	if uids := e.GetAttributeValues("memberUid"); len(uids) == 0 {
+		t.Errorf("posixGroup entry %s has no memberUid values", e.DN)
+	}
+	if members := e.GetAttributeValues("member"); len(members) != 0 {
+		t.Errorf("posixGroup entry %s should not have a member attribute, got %v", e.DN, members)
+	}
+}
+
+func TestGroupMemberAttributeRejectsUnknownObjectClass(t *testing.T) {
+	if _, err := groupMemberAttribute("weirdGroup"); err == nil {
+		t.Fatal("expected an error for an unsupported group objectClass")
+	}
+}
+
+func TestWriteToLDAPReturnsDialErrorWhenServerIsUnreachable(t *testing.T) {
+	cfg := NewRunConfig()
+	cfg.SuffixDN = "ou=people,o=rtx"
+	cfg.LDAPURL = "ldap://127.0.0.1:1"
+	cfg.BindDN = "cn=admin,o=rtx"
+	cfg.BindPassword = "password"
+	cfg.Parallel = 2
+	cfg.TLSConfig = &TLSConfig{InsecureSkipVerify: true}
+
+	entries := []*ldap.Entry{ldap.NewEntry("uid=a,ou=people,o=rtx", map[string][]string{"objectClass": {"top"}})}
+
+	results, err := writeToLDAP(cfg, entries)
+	if err == nil {
+		t.Fatal("expected writeToLDAP to return an error when no worker can dial the server")
+	}
+	if len(results) != 0 {
+		t.Fatalf("expected no results when every worker fails to dial, got %d", len(results))
+	}
+}
+
+func TestWriteToLDAPContinueOnErrorStillReturnsDialError(t *testing.T) {
+	cfg := NewRunConfig()
+	cfg.SuffixDN = "ou=people,o=rtx"
+	cfg.LDAPURL = "ldap://127.0.0.1:1"
+	cfg.BindDN = "cn=admin,o=rtx"
+	cfg.BindPassword = "password"
+	cfg.ContinueOnError = true
+	cfg.TLSConfig = &TLSConfig{InsecureSkipVerify: true}
+
+	entries := []*ldap.Entry{ldap.NewEntry("uid=a,ou=people,o=rtx", map[string][]string{"objectClass": {"top"}})}
+
+	// Even with ContinueOnError set, a worker that can never dial the server
+	// in the first place has no connection to retry entries over, so this
+	// still surfaces as a dial error rather than a per-entry EntryFailed
+	// result.
+	if _, err := writeToLDAP(cfg, entries); err == nil {
+		t.Fatal("expected writeToLDAP to return an error when dialing fails even with ContinueOnError")
+	}
+}
+
+func TestLoadSchemaConfigYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.yaml")
+	yamlContent := "object_classes:\n  - top\n  - posixAccount\nuid_attribute: uid\ncn_attribute: cn\nsn_attribute: sn\nmail_attribute: mail\nextra_attributes:\n  - name: uidNumber\n    expression: counter\n"
+	if err := os.WriteFile(path, []byte(yamlContent), 0o644); err != nil {
+		t.Fatalf("failed to write schema file: %v", err)
+	}
+
+	schema, err := LoadSchemaConfig(path)
+	if err != nil {
+		t.Fatalf("LoadSchemaConfig failed: %v", err)
+	}
+	if len(schema.ObjectClasses) != 2 || schema.ObjectClasses[1] != "posixAccount" {
+		t.Fatalf("expected objectClasses [top posixAccount], got %v", schema.ObjectClasses)
+	}
+	if len(schema.ExtraAttributes) != 1 || schema.ExtraAttributes[0].Name != "uidNumber" {
+		t.Fatalf("expected one uidNumber extra attribute, got %v", schema.ExtraAttributes)
+	}
+}
+
+func TestLoadSchemaConfigJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	jsonContent := `{"object_classes": ["top", "inetOrgPerson"], "uid_attribute": "sAMAccountName"}`
+	if err := os.WriteFile(path, []byte(jsonContent), 0o644); err != nil {
+		t.Fatalf("failed to write schema file: %v", err)
+	}
+
+	schema, err := LoadSchemaConfig(path)
+	if err != nil {
+		t.Fatalf("LoadSchemaConfig failed: %v", err)
+	}
+	if schema.UIDAttribute != "sAMAccountName" {
+		t.Fatalf("expected uid_attribute sAMAccountName, got %q", schema.UIDAttribute)
+	}
+	// Fields the JSON file does not set keep NewSchemaConfig's defaults.
+	if schema.CNAttribute != "cn" {
+		t.Fatalf("expected cn_attribute to keep its default of \"cn\", got %q", schema.CNAttribute)
+	}
+}
+
+func TestLoadSchemaConfigRejectsUnsupportedExtension(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.toml")
+	if err := os.WriteFile(path, []byte("uid_attribute = \"uid\""), 0o644); err != nil {
+		t.Fatalf("failed to write schema file: %v", err)
+	}
+
+	if _, err := LoadSchemaConfig(path); err == nil {
+		t.Fatal("expected an error for an unsupported schema config extension")
+	}
+}
+
+func TestResolveExtraAttributeCounter(t *testing.T) {
+	f := NewFakeEntry("uid=jdoe,ou=people,o=rtx", "jdoe", "John Doe", "Doe", "jdoe@example.com")
+
+	if got := resolveExtraAttribute("counter", f, 42); got != "42" {
+		t.Fatalf("expected resolveExtraAttribute(\"counter\", ..., 42) = \"42\", got %q", got)
+	}
+}
+
+func TestResolveExtraAttributeExpandsPlaceholders(t *testing.T) {
+	f := NewFakeEntry("uid=jdoe,ou=people,o=rtx", "jdoe", "John Doe", "Doe", "jdoe@example.com")
+
+	got := resolveExtraAttribute("/home/{uid}", f, 0)
+	if got != "/home/jdoe" {
+		t.Fatalf("expected /home/jdoe, got %q", got)
+	}
+}
+
+func TestFakeEntryToLDAPEntryUsesSchemaAttributeNamesAndExtras(t *testing.T) {
+	schema := &SchemaConfig{
+		ObjectClasses: []string{"top", "posixAccount"},
+		UIDAttribute:  "uid",
+		CNAttribute:   "cn",
+		SNAttribute:   "sn",
+		MailAttribute: "mail",
+	}
+	f := NewFakeEntry("uid=jdoe,ou=people,o=rtx", "jdoe", "John Doe", "Doe", "jdoe@example.com")
+	f.Extra = map[string][]string{"uidNumber": {"1000"}}
+
+	entry := f.ToLDAPEntry(schema)
+
+	if got := entry.GetAttributeValues("objectClass"); len(got) != 2 || got[1] != "posixAccount" {
+		t.Fatalf("expected objectClass [top posixAccount], got %v", got)
+	}
+	if got := entry.GetAttributeValue("uidNumber"); got != "1000" {
+		t.Fatalf("expected extra attribute uidNumber = 1000, got %q", got)
+	}
+}
+
+func TestFakeEntryToLDAPEntryDefaultsToInetOrgPerson(t *testing.T) {
+	f := NewFakeEntry("uid=jdoe,ou=people,o=rtx", "jdoe", "John Doe", "Doe", "jdoe@example.com")
+
+	entry := f.ToLDAPEntry(nil)
+
+	if got := entry.GetAttributeValues("objectClass"); len(got) != 1 || got[0] != "inetOrgPerson" {
+		t.Fatalf("expected the default objectClass [inetOrgPerson], got %v", got)
+	}
+	if got := entry.GetAttributeValue("uid"); got != "jdoe" {
+		t.Fatalf("expected uid jdoe, got %q", got)
+	}
+}
+
+// writeTestCertAndKey generates a self-signed certificate and writes the
+// certificate and its private key as separate PEM files under dir, returning
+// both paths.
+func writeTestCertAndKey(t *testing.T, dir string) (certPath, keyPath string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate test key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "fakeldap-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		IsCA:         true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create test certificate: %v", err)
+	}
+
+	certPath = filepath.Join(dir, "ca.pem")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644); err != nil {
+		t.Fatalf("failed to write test certificate: %v", err)
+	}
+
+	keyPath = filepath.Join(dir, "key.pem")
+	keyDER := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(keyPath, keyDER, 0o600); err != nil {
+		t.Fatalf("failed to write test key: %v", err)
+	}
+
+	return certPath, keyPath
+}
+
+func TestBuildTLSConfigRequiresCAFileOrInsecure(t *testing.T) {
+	tlsCfg := NewTLSConfig()
+
+	if _, err := buildTLSConfig(tlsCfg); err == nil {
+		t.Fatal("expected an error when neither CAFile nor InsecureSkipVerify is set")
+	}
+}
+
+func TestBuildTLSConfigAllowsInsecureSkipVerifyWithoutCAFile(t *testing.T) {
+	tlsCfg := NewTLSConfig()
+	tlsCfg.InsecureSkipVerify = true
+
+	conf, err := buildTLSConfig(tlsCfg)
+	if err != nil {
+		t.Fatalf("buildTLSConfig failed: %v", err)
+	}
+	if !conf.InsecureSkipVerify {
+		t.Fatal("expected the resulting tls.Config to have InsecureSkipVerify set")
+	}
+}
+
+func TestBuildTLSConfigLoadsCAFile(t *testing.T) {
+	certPath, _ := writeTestCertAndKey(t, t.TempDir())
+
+	tlsCfg := NewTLSConfig()
+	tlsCfg.CAFile = certPath
+	tlsCfg.ServerName = "ldap.example.org"
+
+	conf, err := buildTLSConfig(tlsCfg)
+	if err != nil {
+		t.Fatalf("buildTLSConfig failed: %v", err)
+	}
+	if conf.RootCAs == nil {
+		t.Fatal("expected RootCAs to be populated from CAFile")
+	}
+	if conf.ServerName != "ldap.example.org" {
+		t.Fatalf("expected ServerName to be carried through, got %q", conf.ServerName)
+	}
+}
+
+func TestBuildTLSConfigRejectsMissingCAFile(t *testing.T) {
+	tlsCfg := NewTLSConfig()
+	tlsCfg.CAFile = filepath.Join(t.TempDir(), "does-not-exist.pem")
+
+	if _, err := buildTLSConfig(tlsCfg); err == nil {
+		t.Fatal("expected an error for a CAFile that does not exist")
+	}
+}
+
+func TestBuildTLSConfigRejectsInvalidCAPEM(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bad.pem")
+	if err := os.WriteFile(path, []byte("not a certificate"), 0o644); err != nil {
+		t.Fatalf("failed to write bad CA file: %v", err)
+	}
+
+	tlsCfg := NewTLSConfig()
+	tlsCfg.CAFile = path
+
+	if _, err := buildTLSConfig(tlsCfg); err == nil {
+		t.Fatal("expected an error when CAFile contains no parseable certificates")
+	}
+}
+
+func TestBuildTLSConfigLoadsClientCertificate(t *testing.T) {
+	dir := t.TempDir()
+	certPath, keyPath := writeTestCertAndKey(t, dir)
+
+	tlsCfg := NewTLSConfig()
+	tlsCfg.InsecureSkipVerify = true
+	tlsCfg.ClientCertFile = certPath
+	tlsCfg.ClientKeyFile = keyPath
+
+	conf, err := buildTLSConfig(tlsCfg)
+	if err != nil {
+		t.Fatalf("buildTLSConfig failed: %v", err)
+	}
+	if len(conf.Certificates) != 1 {
+		t.Fatalf("expected 1 client certificate to be loaded, got %d", len(conf.Certificates))
+	}
+}
+
+func TestDialAndBindStartTLSReachesDialStep(t *testing.T) {
+	cfg := NewRunConfig()
+	cfg.LDAPURL = "ldap://127.0.0.1:1"
+	cfg.BindDN = "cn=admin,o=rtx"
+	cfg.BindPassword = "password"
+	cfg.TLSConfig = &TLSConfig{InsecureSkipVerify: true, StartTLS: true}
+
+	// With nothing listening on 127.0.0.1:1, this must fail at the initial
+	// plain-LDAP dial, before StartTLS or Bind ever run, and the error
+	// should say so rather than something about certificate configuration.
+	_, err := dialAndBind(cfg)
+	if err == nil {
+		t.Fatal("expected dialAndBind to fail against an unreachable server")
+	}
+	if !strings.Contains(err.Error(), "failed to connect") {
+		t.Fatalf("expected a connection failure, got: %v", err)
+	}
+}